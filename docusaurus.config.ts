@@ -1,6 +1,8 @@
 import { themes as prismThemes } from 'prism-react-renderer';
 import type { Config } from '@docusaurus/types';
 import type * as Preset from '@docusaurus/preset-classic';
+// Generated by src/connectorgen, contains redirects for renamed connector pages.
+import connectorPages from './static/connector-pages.json';
 
 const config: Config = {
   title: 'Conduit | The Open-Source Kafka Connect Replacement',
@@ -285,6 +287,7 @@ const config: Config = {
             from: '/docs/features/stream-inspector',
             to: '/docs/using/other-features/stream-inspector'
           },
+          ...connectorPages.redirects,
         ]
       },
     ],
@@ -0,0 +1,145 @@
+import React from "react";
+import Chip from '@mui/material/Chip';
+import Link from '@mui/material/Link';
+import Stack from '@mui/material/Stack';
+import Table from '@mui/material/Table';
+import TableBody from '@mui/material/TableBody';
+import TableCell from '@mui/material/TableCell';
+import TableContainer from '@mui/material/TableContainer';
+import TableHead from '@mui/material/TableHead';
+import TableRow from '@mui/material/TableRow';
+import Typography from '@mui/material/Typography';
+import CheckIcon from '@mui/icons-material/Check';
+import {useHistory, useLocation} from '@docusaurus/router';
+import BorderedSection from "@site/src/components/BorderedSection";
+import {TrustLevel} from '@site/src/components/ConnectorList/ConnectorAccordion';
+
+// CatalogEntry is generated by src/connectorgen, see catalogEntry in
+// catalog.go.
+export class CatalogEntry {
+  name: string;
+  name_with_owner: string;
+  url: string;
+  page?: string;
+  summary: string;
+  author: string;
+  source: boolean;
+  destination: boolean;
+  cdc: boolean;
+  categories: string[];
+  latest_version?: string;
+  stars: number;
+  official: boolean;
+  trust: TrustLevel;
+}
+
+type CatalogFilter = 'source' | 'destination' | 'cdc' | 'official';
+
+const filters: {id: CatalogFilter, label: string}[] = [
+  {id: 'source', label: 'Source'},
+  {id: 'destination', label: 'Destination'},
+  {id: 'cdc', label: 'CDC'},
+  {id: 'official', label: 'Official'},
+];
+
+// The active filters are stored in the query string (e.g. ?filter=source,cdc),
+// so they are shared by all tables on the page and can be linked to.
+const filterParam = 'filter';
+
+function useActiveFilters(): [CatalogFilter[], (filters: CatalogFilter[]) => void] {
+  const location = useLocation();
+  const history = useHistory();
+
+  const params = new URLSearchParams(location.search);
+  const active = (params.get(filterParam) ?? '')
+    .split(',')
+    .filter((f) => filters.some((known) => known.id === f)) as CatalogFilter[];
+
+  const setActive = (next: CatalogFilter[]) => {
+    if (next.length > 0) {
+      params.set(filterParam, next.join(','));
+    } else {
+      params.delete(filterParam);
+    }
+    const search = params.toString();
+    history.replace({...location, search: search ? '?' + search : ''});
+  };
+  return [active, setActive];
+}
+
+export function CatalogFilters() {
+  const [active, setActive] = useActiveFilters();
+
+  const toggle = (filter: CatalogFilter) => {
+    if (active.includes(filter)) {
+      setActive(active.filter((f) => f !== filter));
+    } else {
+      setActive([...active, filter]);
+    }
+  };
+
+  return (
+    <BorderedSection title="Filters">
+      <Stack direction='row' spacing={1}>
+        {filters.map((f) => (
+          <Chip
+            key={f.id}
+            label={f.label}
+            color={active.includes(f.id) ? 'primary' : 'default'}
+            variant={active.includes(f.id) ? 'filled' : 'outlined'}
+            onClick={() => toggle(f.id)}
+          />
+        ))}
+      </Stack>
+    </BorderedSection>
+  );
+}
+
+function Check({value}: {value: boolean}) {
+  return value ? <CheckIcon fontSize='small' titleAccess='yes' /> : null;
+}
+
+export default function ConnectorCatalog({connectors}: {connectors: CatalogEntry[]}) {
+  const [active] = useActiveFilters();
+  const shown = connectors.filter((c) => active.every((f) => c[f]));
+
+  if (shown.length == 0) {
+    return <Typography>No connectors in this category match the filters.</Typography>;
+  }
+  return (
+    <TableContainer>
+      <Table size='small'>
+        <TableHead>
+          <TableRow>
+            <TableCell>Connector</TableCell>
+            <TableCell>Summary</TableCell>
+            <TableCell>Source</TableCell>
+            <TableCell>Destination</TableCell>
+            <TableCell>CDC</TableCell>
+            <TableCell>Latest version</TableCell>
+            <TableCell>Stars</TableCell>
+          </TableRow>
+        </TableHead>
+        <TableBody>
+          {shown.map((c) => (
+            <TableRow key={c.name_with_owner}>
+              <TableCell>
+                <Stack direction='row' spacing={1} alignItems='center'>
+                  <Link href={c.page ?? c.url}>{c.name}</Link>
+                  {c.official && <Chip label='Official' size='small' />}
+                </Stack>
+                <Typography variant='caption'>{c.name_with_owner}</Typography>
+              </TableCell>
+              <TableCell>{c.summary}</TableCell>
+              <TableCell><Check value={c.source} /></TableCell>
+              <TableCell><Check value={c.destination} /></TableCell>
+              <TableCell><Check value={c.cdc} /></TableCell>
+              <TableCell>{c.latest_version ?? '—'}</TableCell>
+              <TableCell>{c.stars}</TableCell>
+            </TableRow>
+          ))}
+        </TableBody>
+      </Table>
+    </TableContainer>
+  );
+}
@@ -22,9 +22,48 @@ export class Connector {
   created_at: string;
   stargazer_count: number;
   fork_count: number;
+  // trust is set by src/connectorgen, see trust-config.yaml.
+  trust?: TrustLevel;
   releases: Release[];
 }
 
+export type TrustLevel = 'official' | 'labs' | 'community';
+
+// trustLevel returns the trust level of the connector. Connectors fetched
+// before the trust level was recorded are classified by their owner.
+export function trustLevel(connector: Connector): TrustLevel {
+  if (connector.trust) {
+    return connector.trust;
+  }
+  const owner = connector.name_with_owner.toLowerCase().split('/')[0];
+  if (owner === 'conduitio') {
+    return 'official';
+  }
+  if (owner === 'conduitio-labs') {
+    return 'labs';
+  }
+  return 'community';
+}
+
+export function TrustBadge({connector}: {connector: Connector}) {
+  switch (trustLevel(connector)) {
+    case 'official':
+      return (
+        <Tooltip title="Maintained by the Conduit team">
+          <img src="/img/conduit/conduit-ring.png" width="18" alt="Conduit team logo" />
+        </Tooltip>
+      );
+    case 'labs':
+      return (
+        <Tooltip title="Maintained by the Conduit team as part of Conduit Labs">
+          <img src="/img/conduit/conduit-ring.png" width="18" alt="Conduit team logo" />
+        </Tooltip>
+      );
+    default:
+      return null;
+  }
+}
+
 export interface ConnectorAccordionProps extends AccordionProps {
   connector: Connector;
 }
@@ -74,12 +113,7 @@ export const NonExpandableAccordion = styled((props: ConnectorAccordionProps) =>
           </Typography>
         </Stack>
         <Stack direction="row" spacing={1} alignItems="center">
-          {props.connector.name_with_owner.toLowerCase().startsWith('conduitio/') ||
-          props.connector.name_with_owner.toLowerCase().startsWith('conduitio-labs/') ? (
-            <Tooltip title="Created by the Conduit team">
-              <img src="/img/conduit/conduit-ring.png" width="18" alt="Conduit team logo" />
-            </Tooltip>
-          ) : null}
+          <TrustBadge connector={props.connector} />
           <IconButton size="small" href={props.connector.url} target="_blank" onClick={stopPropagation}>
             <GitHubIcon fontSize="inherit" />
           </IconButton>
@@ -118,12 +152,7 @@ export const ConnectorAccordionSummary = styled((props: ConnectorAccordionSummar
         </Typography>
       </Stack>
       <Stack direction="row" spacing={1} alignItems="center">
-        {props.connector.name_with_owner.toLowerCase().startsWith('conduitio/') ||
-        props.connector.name_with_owner.toLowerCase().startsWith('conduitio-labs/') ? (
-          <Tooltip title="Created by the Conduit team">
-            <img src="/img/conduit/conduit-ring.png" width="18" alt="Conduit team logo" />
-          </Tooltip>
-        ) : null}
+        <TrustBadge connector={props.connector} />
         <IconButton size="small" href={props.connector.url} target="_blank" onClick={stopPropagation}>
           <GitHubIcon fontSize="inherit" />
         </IconButton>
@@ -1,4 +1,4 @@
-import {ConnectorAccordion, NonExpandableAccordion, Connector} from '@site/src/components/ConnectorList/ConnectorAccordion';
+import {ConnectorAccordion, NonExpandableAccordion, Connector, trustLevel} from '@site/src/components/ConnectorList/ConnectorAccordion';
 import TextField from '@mui/material/TextField';
 import InputAdornment from '@mui/material/InputAdornment';
 import SearchIcon from '@mui/icons-material/Search';
@@ -27,9 +27,7 @@ class Filter {
     if (!this.showWithoutRelease && (!connector.releases || connector.releases.length == 0)) {
       return false;
     }
-    if (!this.showCommunity &&
-      !connector.name_with_owner.toLowerCase().startsWith('conduitio/') &&
-      !connector.name_with_owner.toLowerCase().startsWith('conduitio-labs/')) {
+    if (!this.showCommunity && trustLevel(connector) === 'community') {
       return false;
     }
     if (this.nameWithOwnerQuery &&
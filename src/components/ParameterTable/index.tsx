@@ -0,0 +1,118 @@
+import React from "react";
+import Chip from '@mui/material/Chip';
+import Stack from '@mui/material/Stack';
+import Table from '@mui/material/Table';
+import TableBody from '@mui/material/TableBody';
+import TableCell from '@mui/material/TableCell';
+import TableContainer from '@mui/material/TableContainer';
+import TableHead from '@mui/material/TableHead';
+import TableRow from '@mui/material/TableRow';
+import TableSortLabel from '@mui/material/TableSortLabel';
+
+import Markdown from 'react-markdown';
+
+// Parameter is generated by src/connectorgen, see parameterTable in docs.go.
+export class Parameter {
+  name: string;
+  type: string;
+  required: boolean;
+  default: string;
+  description: string;
+  validations: string[];
+}
+
+type SortColumn = 'name' | 'type' | 'required' | 'default';
+type SortDirection = 'asc' | 'desc';
+
+class ParameterTableState {
+  orderBy: SortColumn | null;
+  order: SortDirection;
+}
+
+const columns: {id: SortColumn, label: string}[] = [
+  {id: 'name', label: 'Name'},
+  {id: 'type', label: 'Type'},
+  {id: 'required', label: 'Required'},
+  {id: 'default', label: 'Default'},
+];
+
+class ParameterTable extends React.Component<{parameters: Parameter[]}, ParameterTableState> {
+  constructor(props: {parameters: Parameter[]}) {
+    super(props);
+    // The parameters are already sorted with required parameters first, keep
+    // that order until the user picks a column.
+    this.state = {
+      orderBy: null,
+      order: 'asc',
+    }
+  }
+
+  sortBy(column: SortColumn) {
+    const isAsc = this.state.orderBy === column && this.state.order === 'asc';
+    this.setState({orderBy: column, order: isAsc ? 'desc' : 'asc'});
+  }
+
+  sortedParameters(): Parameter[] {
+    const {orderBy, order} = this.state;
+    if (!orderBy) {
+      return this.props.parameters;
+    }
+    return [...this.props.parameters].sort((a: Parameter, b: Parameter): number => {
+      const aValue = String(a[orderBy]);
+      const bValue = String(b[orderBy]);
+      const cmp = aValue.localeCompare(bValue);
+      return order === 'asc' ? cmp : -cmp;
+    });
+  }
+
+  render() {
+    if (!this.props.parameters || this.props.parameters.length == 0) {
+      return <p>No parameters.</p>;
+    }
+
+    return (
+      <TableContainer>
+        <Table size='small' className='no-margin-table'>
+          <TableHead>
+            <TableRow>
+              {columns.map((column) => (
+                <TableCell key={column.id}>
+                  <TableSortLabel
+                    active={this.state.orderBy === column.id}
+                    direction={this.state.orderBy === column.id ? this.state.order : 'asc'}
+                    onClick={() => this.sortBy(column.id)}
+                  >
+                    {column.label}
+                  </TableSortLabel>
+                </TableCell>
+              ))}
+              <TableCell>Description</TableCell>
+            </TableRow>
+          </TableHead>
+          <TableBody>
+            {this.sortedParameters().map((param) => (
+              <TableRow key={param.name}>
+                <TableCell><code>{param.name}</code></TableCell>
+                <TableCell>{param.type}</TableCell>
+                <TableCell>{param.required ? 'yes' : 'no'}</TableCell>
+                <TableCell>{param.default ? <code>{param.default}</code> : <Chip label="null" size='small' />}</TableCell>
+                <TableCell>
+                  <Markdown>{param.description}</Markdown>
+                  {param.validations && param.validations.length > 0 &&
+                    <Stack direction='row' spacing={1} useFlexGap flexWrap='wrap'>
+                      {param.validations.map((validation) => (
+                        <Chip key={validation} label={validation} size='small' variant='outlined' />
+                      ))}
+                    </Stack>
+                  }
+                </TableCell>
+              </TableRow>
+            ))}
+          </TableBody>
+        </Table>
+      </TableContainer>
+    );
+  }
+}
+
+export default ParameterTable;
@@ -0,0 +1,344 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/conduitio/conduit-connector-protocol/pconnector"
+	"github.com/conduitio/conduit-connector-protocol/pconnector/client"
+	"github.com/conduitio/conduit-connector-protocol/pconnutils"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+)
+
+const (
+	// binarySpecifyTimeout is the maximum time a connector binary has to start
+	// and return its specification.
+	binarySpecifyTimeout = 30 * time.Second
+	// maxArchiveSize is the maximum size of a release archive we are willing
+	// to download.
+	maxArchiveSize = 256 << 20 // 256 MiB
+	// sandboxInitArg is the first argument when connectorgen re-executes itself
+	// to start a connector binary in a sandbox, see sandboxCommand.
+	sandboxInitArg = "__sandbox-init"
+)
+
+var errNoBinaryAsset = errors.New("no linux/amd64 release asset found")
+
+// binaryAsset returns the linux/amd64 asset of the release. Only linux/amd64 is
+// considered, as that is the platform the tool runs on in CI.
+func binaryAsset(release Release) (Asset, bool) {
+	for _, asset := range release.Assets {
+		if asset.OS == "linux" && asset.Arch == "amd64" &&
+			strings.HasSuffix(asset.Name, ".tar.gz") {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// githubDownloadURL returns the GitHub download URL of the asset. The registry
+// stores scarf links to track downloads by users, fetching specifications
+// should not be counted as a download.
+func githubDownloadURL(asset Asset) string {
+	return strings.Replace(asset.BrowserDownload, "conduit.gateway.scarf.sh/connector/download", "github.com", 1)
+}
+
+// specificationFromBinary downloads the linux/amd64 release archive, extracts
+// the connector binary and retrieves the specification from it using the
+// connector plugin protocol. The specification is returned as connector.yaml
+// content.
+func (cmd *CommandSpecifications) specificationFromBinary(ctx context.Context, repoName string, asset Asset) ([]byte, error) {
+	tmpDir, err := os.MkdirTemp("", "connectorgen-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	archivePath := filepath.Join(tmpDir, asset.Name)
+	if err := downloadFile(ctx, githubDownloadURL(asset), archivePath); err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	}
+
+	binaryPath, err := extractBinary(archivePath, tmpDir, repoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract connector binary from %s: %w", asset.Name, err)
+	}
+
+	spec, err := specifyBinary(ctx, binaryPath, tmpDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get specification from connector binary: %w", err)
+	}
+
+	return specificationToYAML(spec)
+}
+
+func downloadFile(ctx context.Context, url, dst string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	n, err := io.Copy(f, io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return err
+	}
+	if n > maxArchiveSize {
+		return fmt.Errorf("archive exceeds the maximum size of %d bytes", maxArchiveSize)
+	}
+	return nil
+}
+
+// extractBinary extracts the connector binary from a .tar.gz archive into dir
+// and returns its path. The binary is the executable file named after the
+// repository or, if there is none, the only executable in the archive.
+func extractBinary(archivePath, dir, repoName string) (string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", err
+	}
+	defer gz.Close()
+
+	var executables []string
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return "", err
+		}
+
+		if hdr.Typeflag != tar.TypeReg || hdr.FileInfo().Mode()&0o111 == 0 {
+			// only regular executable files are of interest
+			continue
+		}
+
+		// Only use the base name, so that entries can't escape dir
+		name := path.Base(hdr.Name)
+		if name == "." || name == "/" {
+			continue
+		}
+		dst := filepath.Join(dir, "bin", name)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return "", err
+		}
+		if err := writeExecutable(dst, tr); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", name, err)
+		}
+
+		if name == repoName {
+			return dst, nil
+		}
+		executables = append(executables, dst)
+	}
+
+	switch len(executables) {
+	case 0:
+		return "", errors.New("no executable found in archive")
+	case 1:
+		return executables[0], nil
+	default:
+		return "", fmt.Errorf("found %d executables in archive, none named %s", len(executables), repoName)
+	}
+}
+
+func writeExecutable(dst string, r io.Reader) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, io.LimitReader(r, maxArchiveSize))
+	return err
+}
+
+// specifyBinary starts the connector binary as a plugin and requests its
+// specification. The plugin runs in a sandbox (see sandboxCommand): it gets a
+// clean environment (e.g. no GITHUB_TOKEN), has no network access, can only
+// write to workDir and is killed after binarySpecifyTimeout.
+func specifyBinary(ctx context.Context, binaryPath, workDir string) (pconnector.Specification, error) {
+	ctx, cancel := context.WithTimeout(ctx, binarySpecifyTimeout)
+	defer cancel()
+
+	c, err := client.New(hclog.NewNullLogger(), binaryPath, pluginOption{workDir: workDir})
+	if err != nil {
+		return pconnector.Specification{}, err
+	}
+	defer c.Kill()
+
+	type result struct {
+		spec pconnector.Specification
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		spec, err := dispenseSpecification(ctx, c)
+		done <- result{spec: spec, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return pconnector.Specification{}, fmt.Errorf("connector did not return specification in time: %w", ctx.Err())
+	case r := <-done:
+		return r.spec, r.err
+	}
+}
+
+func dispenseSpecification(ctx context.Context, c *plugin.Client) (pconnector.Specification, error) {
+	rpcClient, err := c.Client()
+	if err != nil {
+		return pconnector.Specification{}, fmt.Errorf("failed to start plugin: %w", err)
+	}
+
+	raw, err := rpcClient.Dispense("specifier")
+	if err != nil {
+		return pconnector.Specification{}, fmt.Errorf("failed to dispense specifier: %w", err)
+	}
+	specifier, ok := raw.(pconnector.SpecifierPlugin)
+	if !ok {
+		return pconnector.Specification{}, fmt.Errorf("unexpected specifier type %T", raw)
+	}
+
+	resp, err := specifier.Specify(ctx, pconnector.SpecifierSpecifyRequest{})
+	if err != nil {
+		return pconnector.Specification{}, err
+	}
+	return resp.Specification, nil
+}
+
+// pluginOption configures the plugin process started by client.New.
+type pluginOption struct {
+	workDir string
+}
+
+func (o pluginOption) ApplyOption(cfg *plugin.ClientConfig) error {
+	cfg.StartTimeout = binarySpecifyTimeout
+	// The plugin must not inherit the environment of connectorgen (e.g.
+	// GITHUB_TOKEN). Newer SDK versions refuse to start without these
+	// variables, the utilities target is never dialled when only the
+	// specification is requested.
+	cfg.SkipHostEnv = true
+	cfg.Cmd.Env = []string{
+		pconnector.EnvConduitConnectorID + "=connectorgen",
+		pconnutils.EnvConduitConnectorToken + "=connectorgen",
+		pconnutils.EnvConduitConnectorUtilitiesGRPCTarget + "=127.0.0.1:0",
+		// the plugin creates its unix socket in the temporary directory,
+		// workDir is the only writable directory in the sandbox
+		"TMPDIR=" + o.workDir,
+	}
+	cfg.Cmd.Dir = o.workDir
+	return sandboxCommand(cfg.Cmd, o.workDir)
+}
+
+// specificationToYAML converts the specification into the connector.yaml
+// format, the same way the SDK generates connector.yaml files.
+func specificationToYAML(spec pconnector.Specification) ([]byte, error) {
+	toPlugin := func(params config.Parameters) *PluginSpecification {
+		if len(params) == 0 {
+			return nil
+		}
+
+		out := make([]Parameter, 0, len(params))
+		for name, param := range params {
+			p := Parameter{
+				Name:        name,
+				Description: param.Description,
+				Type:        param.Type.String(),
+				Default:     param.Default,
+				Validations: make([]Validation, len(param.Validations)),
+			}
+			for i, v := range param.Validations {
+				p.Validations[i] = Validation{Type: v.Type().String(), Value: v.Value()}
+			}
+			out = append(out, p)
+		}
+		// Connector parameters come before SDK parameters, required before
+		// optional ones, then sorted by name.
+		slices.SortFunc(out, func(a, b Parameter) int {
+			if isSDKParameter(a.Name) != isSDKParameter(b.Name) {
+				return boolCompare(isSDKParameter(a.Name), isSDKParameter(b.Name))
+			}
+			if a.IsRequired() != b.IsRequired() {
+				return boolCompare(b.IsRequired(), a.IsRequired())
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+		return &PluginSpecification{Parameters: out}
+	}
+
+	return encodeConnectorYAML(Specification{
+		Name:        spec.Name,
+		Summary:     spec.Summary,
+		Description: spec.Description,
+		Version:     spec.Version,
+		Author:      spec.Author,
+		Source:      toPlugin(spec.SourceParams),
+		Destination: toPlugin(spec.DestinationParams),
+	})
+}
+
+func isSDKParameter(name string) bool {
+	return strings.HasPrefix(name, "sdk.")
+}
+
+// boolCompare orders false before true.
+func boolCompare(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeArchive(t *testing.T, path string, files map[string]int64) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, mode := range files {
+		content := []byte("content of " + name)
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     mode,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]int64
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "binary named after repository",
+			files: map[string]int64{
+				"LICENSE.md":             0644,
+				"helper":                 0755,
+				"conduit-connector-file": 0755,
+			},
+			want: "conduit-connector-file",
+		},
+		{
+			name: "single executable",
+			files: map[string]int64{
+				"README.md": 0644,
+				"connector": 0755,
+			},
+			want: "connector",
+		},
+		{
+			name: "entries can't escape the target directory",
+			files: map[string]int64{
+				"../../connector": 0755,
+			},
+			want: "connector",
+		},
+		{
+			name: "no executable",
+			files: map[string]int64{
+				"README.md": 0644,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ambiguous executables",
+			files: map[string]int64{
+				"foo": 0755,
+				"bar": 0755,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			archivePath := filepath.Join(dir, "archive.tar.gz")
+			writeArchive(t, archivePath, tt.files)
+
+			got, err := extractBinary(archivePath, dir, "conduit-connector-file")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractBinary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if want := filepath.Join(dir, "bin", tt.want); got != want {
+				t.Errorf("extractBinary() = %q, want %q", got, want)
+			}
+		})
+	}
+}
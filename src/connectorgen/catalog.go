@@ -0,0 +1,352 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/conduitio/genfuncs"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed catalog-config.yaml
+var catalogConfigYaml []byte
+
+//go:embed templates/catalog.mdx.tmpl
+var catalogTemplate string
+
+// categoryOther is the category of connectors that don't match any
+// configured category.
+const categoryOther = "Other"
+
+// cdcTopic is the GitHub topic marking a repository as supporting CDC.
+const cdcTopic = "cdc"
+
+type catalogConfig struct {
+	// Categories lists the categories in the order they are shown.
+	Categories []string `yaml:"categories"`
+	// Topics maps GitHub topics to categories.
+	Topics map[string]string `yaml:"topics"`
+	// Connectors maps repositories to categories.
+	Connectors map[string][]string `yaml:"connectors"`
+	// CDC lists repositories whose source supports change data capture.
+	CDC []string `yaml:"cdc"`
+}
+
+// catalog is the normalised list of connectors written to catalog.json.
+type catalog struct {
+	// Categories contains the categories that have at least one connector,
+	// in the order they are shown.
+	Categories []string       `json:"categories"`
+	Connectors []catalogEntry `json:"connectors"`
+}
+
+type catalogEntry struct {
+	Name          string   `json:"name"`
+	NameWithOwner string   `json:"name_with_owner"`
+	URL           string   `json:"url"`
+	Page          string   `json:"page,omitempty"`
+	Summary       string   `json:"summary"`
+	Author        string   `json:"author"`
+	Source        bool     `json:"source"`
+	Destination   bool     `json:"destination"`
+	CDC           bool     `json:"cdc"`
+	Categories    []string `json:"categories"`
+	LatestVersion string   `json:"latest_version,omitempty"`
+	Stars         int      `json:"stars"`
+	// Official is true if the connector is maintained by the Conduit team,
+	// Trust contains the detailed trust level.
+	Official bool       `json:"official"`
+	Trust    TrustLevel `json:"trust"`
+}
+
+// CommandCatalog generates catalog.json, a normalised list of all connectors
+// with their capabilities and categories, and a page listing the connectors
+// grouped by category.
+type CommandCatalog struct {
+	connectorsFile string
+	specsFolder    string
+	pageIndexFile  string
+	outputFile     string
+	pageFile       string
+}
+
+func NewCommandCatalog(connectorsFile, specsFolder, pageIndexFile, outputFile, pageFile string) *CommandCatalog {
+	return &CommandCatalog{
+		connectorsFile: connectorsFile,
+		specsFolder:    specsFolder,
+		pageIndexFile:  pageIndexFile,
+		outputFile:     outputFile,
+		pageFile:       pageFile,
+	}
+}
+
+func (cmd *CommandCatalog) Execute(context.Context) error {
+	cfg, err := parseCatalogConfig(catalogConfigYaml)
+	if err != nil {
+		return err
+	}
+	trustCfg, err := parseTrustConfig(trustConfigYaml)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("👀 Reading %s ...\n", cmd.connectorsFile)
+
+	data, err := os.ReadFile(cmd.connectorsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	var repositories []Repository
+	if err := json.Unmarshal(data, &repositories); err != nil {
+		return fmt.Errorf("failed to parse JSON input: %w", err)
+	}
+
+	index, err := readPageIndex(cmd.pageIndexFile)
+	if err != nil {
+		return err
+	}
+
+	// loading specifications works the same as for pages
+	docs := NewCommandDocs(cmd.connectorsFile, cmd.specsFolder, cmd.pageIndexFile, nil)
+
+	entries := make([]catalogEntry, 0, len(repositories))
+	for _, repo := range repositories {
+		fmt.Printf("\n🕵  Processing repository %v\n", repo.NameWithOwner)
+
+		repo.Trust = trustCfg.classify(repo)
+		entry := cfg.entry(repo, docs.loadSpecifications(repo))
+		if slug, ok := index.Pages[repo.NameWithOwner]; ok {
+			entry.Page = connectorPagesURL + slug
+		}
+		if slices.Equal(entry.Categories, []string{categoryOther}) {
+			fmt.Printf("  ⚠️  Warning: no category found for %s\n", repo.NameWithOwner)
+		}
+		entries = append(entries, entry)
+	}
+	c := cfg.catalog(entries)
+
+	fmt.Printf("\n💾 Saving catalog to %s ...\n", cmd.outputFile)
+	raw, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal catalog: %w", err)
+	}
+	if err := os.WriteFile(cmd.outputFile, append(raw, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write catalog: %w", err)
+	}
+
+	fmt.Printf("💾 Writing %s ...\n", cmd.pageFile)
+	page, err := renderCatalogPage(c)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(cmd.pageFile, page, 0644); err != nil {
+		return fmt.Errorf("failed to write catalog page: %w", err)
+	}
+
+	fmt.Printf("✅ Cataloged %d connectors in %d categories\n", len(c.Connectors), len(c.Categories))
+	return nil
+}
+
+func parseCatalogConfig(raw []byte) (catalogConfig, error) {
+	var cfg catalogConfig
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return catalogConfig{}, fmt.Errorf("failed to parse catalog-config.yaml: %w", err)
+	}
+
+	known := make(map[string]bool, len(cfg.Categories))
+	for _, c := range cfg.Categories {
+		known[c] = true
+	}
+	checkCategory := func(category, source string) error {
+		if !known[category] {
+			return fmt.Errorf("unknown category %q used by %s in catalog-config.yaml", category, source)
+		}
+		return nil
+	}
+
+	// Normalize keys, so lookups are case-insensitive
+	topics := make(map[string]string, len(cfg.Topics))
+	for topic, category := range cfg.Topics {
+		if err := checkCategory(category, "topic "+topic); err != nil {
+			return catalogConfig{}, err
+		}
+		topics[strings.ToLower(topic)] = category
+	}
+	cfg.Topics = topics
+
+	connectors := make(map[string][]string, len(cfg.Connectors))
+	for repo, categories := range cfg.Connectors {
+		for _, category := range categories {
+			if err := checkCategory(category, repo); err != nil {
+				return catalogConfig{}, err
+			}
+		}
+		connectors[strings.ToLower(repo)] = categories
+	}
+	cfg.Connectors = connectors
+
+	for i := range cfg.CDC {
+		cfg.CDC[i] = strings.ToLower(cfg.CDC[i])
+	}
+
+	return cfg, nil
+}
+
+// entry creates the catalog entry of a repository. Values are taken from the
+// specification of the latest release and fall back to the repository
+// information if there is none. The trust level of the repository needs to be
+// classified beforehand.
+func (cfg catalogConfig) entry(repo Repository, specifications map[string]Specification) catalogEntry {
+	owner, repoName, _ := strings.Cut(repo.NameWithOwner, "/")
+	latest := specifications["latest"]
+
+	entry := catalogEntry{
+		Name:          latest.Name,
+		NameWithOwner: repo.NameWithOwner,
+		URL:           repo.URL,
+		Summary:       latest.Summary,
+		Author:        latest.Author,
+		Source:        latest.Source != nil,
+		Destination:   latest.Destination != nil,
+		CDC:           cfg.isCDC(repo),
+		Categories:    cfg.categories(repo),
+		Stars:         repo.Stargazers,
+		Official:      repo.Trust.IsConduitTeam(),
+		Trust:         repo.Trust,
+	}
+	if entry.Name == "" {
+		entry.Name = strings.TrimPrefix(repoName, "conduit-connector-")
+	}
+	if entry.Summary == "" {
+		entry.Summary = strings.TrimSpace(repo.Description)
+	}
+	if entry.Author == "" {
+		entry.Author = owner
+	}
+	for _, release := range repo.Releases {
+		if release.IsLatest {
+			entry.LatestVersion = release.TagName
+		}
+	}
+	return entry
+}
+
+// categories returns the categories of a repository derived from its topics
+// and the configured categories, in the configured order.
+func (cfg catalogConfig) categories(repo Repository) []string {
+	matched := map[string]bool{}
+	for _, topic := range repo.Topics {
+		if category, ok := cfg.Topics[strings.ToLower(topic)]; ok {
+			matched[category] = true
+		}
+	}
+	for _, category := range cfg.Connectors[strings.ToLower(repo.NameWithOwner)] {
+		matched[category] = true
+	}
+
+	var categories []string
+	for _, category := range cfg.Categories {
+		if matched[category] {
+			categories = append(categories, category)
+		}
+	}
+	if len(categories) == 0 {
+		return []string{categoryOther}
+	}
+	return categories
+}
+
+func (cfg catalogConfig) isCDC(repo Repository) bool {
+	if slices.Contains(cfg.CDC, strings.ToLower(repo.NameWithOwner)) {
+		return true
+	}
+	return slices.ContainsFunc(repo.Topics, func(topic string) bool {
+		return strings.EqualFold(topic, cdcTopic)
+	})
+}
+
+// catalog returns the catalog containing the entries and the categories in
+// use, "Other" is always the last category.
+func (cfg catalogConfig) catalog(entries []catalogEntry) catalog {
+	used := map[string]bool{}
+	for _, e := range entries {
+		for _, category := range e.Categories {
+			used[category] = true
+		}
+	}
+
+	c := catalog{Categories: []string{}, Connectors: entries}
+	for _, category := range append(slices.Clone(cfg.Categories), categoryOther) {
+		if used[category] {
+			c.Categories = append(c.Categories, category)
+		}
+	}
+	return c
+}
+
+// catalogSection is a category on the catalog page.
+type catalogSection struct {
+	Category   string
+	Connectors []catalogEntry
+}
+
+// sections groups the connectors by category, connectors are sorted by name.
+// Connectors in multiple categories are listed in each of them.
+func (c catalog) sections() []catalogSection {
+	sections := make([]catalogSection, len(c.Categories))
+	for i, category := range c.Categories {
+		sections[i].Category = category
+		for _, e := range c.Connectors {
+			if slices.Contains(e.Categories, category) {
+				sections[i].Connectors = append(sections[i].Connectors, e)
+			}
+		}
+		slices.SortStableFunc(sections[i].Connectors, func(a, b catalogEntry) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	}
+	return sections
+}
+
+// renderCatalogPage renders the page listing the connectors grouped by
+// category.
+func renderCatalogPage(c catalog) ([]byte, error) {
+	tmpl, err := template.New("catalog").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			raw, err := json.Marshal(v)
+			return string(raw), err
+		},
+		"mdx": genfuncs.EscapeMDX,
+	}).Parse(catalogTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse catalog template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, c.sections()); err != nil {
+		return nil, fmt.Errorf("failed to render catalog page: %w", err)
+	}
+	return rewriteDomain(buf.Bytes()), nil
+}
@@ -0,0 +1,153 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/genfuncs"
+)
+
+func TestParseCatalogConfig(t *testing.T) {
+	if _, err := parseCatalogConfig(catalogConfigYaml); err != nil {
+		t.Fatalf("embedded catalog-config.yaml is invalid: %v", err)
+	}
+
+	_, err := parseCatalogConfig([]byte("categories: [Databases]\ntopics:\n  kafka: Messaging\n"))
+	if err == nil || !strings.Contains(err.Error(), `unknown category "Messaging"`) {
+		t.Errorf("expected unknown category error, got %v", err)
+	}
+}
+
+func TestCatalogEntry(t *testing.T) {
+	cfg, err := parseCatalogConfig([]byte(`
+categories: [Databases, Messaging]
+topics:
+  Kafka: Messaging
+connectors:
+  ConduitIO/conduit-connector-postgres: [Databases]
+cdc: [ConduitIO/conduit-connector-postgres]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		repo  Repository
+		specs map[string]Specification
+		want  catalogEntry
+	}{{
+		name: "from specification",
+		repo: Repository{
+			NameWithOwner: "conduitio/conduit-connector-postgres",
+			URL:           "https://github.com/ConduitIO/conduit-connector-postgres",
+			Description:   "Conduit connector for PostgreSQL",
+			Stargazers:    12,
+			Topics:        []string{"kafka"},
+			Trust:         TrustOfficial,
+			Releases: []Release{
+				{TagName: "v0.2.0", IsLatest: true},
+				{TagName: "v0.1.0"},
+			},
+		},
+		specs: map[string]Specification{
+			"latest": {
+				Name:    "postgres",
+				Summary: "Postgres source and destination",
+				Author:  "Meroxa, Inc.",
+				Source:  &PluginSpecification{},
+			},
+		},
+		want: catalogEntry{
+			Name:          "postgres",
+			NameWithOwner: "conduitio/conduit-connector-postgres",
+			URL:           "https://github.com/ConduitIO/conduit-connector-postgres",
+			Summary:       "Postgres source and destination",
+			Author:        "Meroxa, Inc.",
+			Source:        true,
+			CDC:           true,
+			Categories:    []string{"Databases", "Messaging"},
+			LatestVersion: "v0.2.0",
+			Stars:         12,
+			Official:      true,
+			Trust:         TrustOfficial,
+		},
+	}, {
+		name: "without specification",
+		repo: Repository{
+			NameWithOwner: "acme/conduit-connector-foo",
+			Description:   " Conduit connector for Foo ",
+			Topics:        []string{"CDC"},
+			Trust:         TrustCommunity,
+		},
+		want: catalogEntry{
+			Name:          "foo",
+			NameWithOwner: "acme/conduit-connector-foo",
+			Summary:       "Conduit connector for Foo",
+			Author:        "acme",
+			CDC:           true,
+			Categories:    []string{categoryOther},
+			Trust:         TrustCommunity,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cfg.entry(tt.repo, tt.specs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected\n%+v\ngot\n%+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCatalogPage(t *testing.T) {
+	cfg := catalogConfig{Categories: []string{"Databases", "Messaging", "Unused"}}
+	c := cfg.catalog([]catalogEntry{
+		{Name: "redis", Categories: []string{"Databases", "Messaging"}},
+		{Name: "foo", Categories: []string{categoryOther}},
+		{Name: "mysql", Categories: []string{"Databases"}},
+	})
+
+	wantCategories := []string{"Databases", "Messaging", categoryOther}
+	if !reflect.DeepEqual(c.Categories, wantCategories) {
+		t.Errorf("expected categories %v, got %v", wantCategories, c.Categories)
+	}
+
+	page, err := renderCatalogPage(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if problems := genfuncs.LintMDX(string(page)); len(problems) > 0 {
+		t.Errorf("catalog page contains MDX problems: %v", problems)
+	}
+
+	// connectors are sorted by name in each category
+	got := string(page)
+	for _, want := range []string{
+		"## Databases\n\n<ConnectorCatalog connectors={[{\"name\":\"mysql\"",
+		"\"name\":\"redis\"",
+		"## Other\n\n<ConnectorCatalog connectors={[{\"name\":\"foo\"",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected page to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "## Messaging") > strings.Index(got, "## Other") {
+		t.Errorf("expected Other to be the last category")
+	}
+}
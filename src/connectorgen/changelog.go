@@ -0,0 +1,306 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/config"
+)
+
+// ChangeKind describes how a parameter changed between two releases.
+type ChangeKind string
+
+const (
+	ChangeAdded                ChangeKind = "added"
+	ChangeRemoved              ChangeKind = "removed"
+	ChangeRenamed              ChangeKind = "renamed"
+	ChangeDefaultChanged       ChangeKind = "default changed"
+	ChangeTypeChanged          ChangeKind = "type changed"
+	ChangeValidationsTightened ChangeKind = "validations tightened"
+	ChangeValidationsRelaxed   ChangeKind = "validations relaxed"
+)
+
+// ParameterChange is a single change of a source or destination parameter.
+type ParameterChange struct {
+	// Plugin is either "source" or "destination".
+	Plugin    string
+	Parameter string
+	Kind      ChangeKind
+	// Details is a human readable description of the change, formatted as
+	// markdown.
+	Details string
+	// Breaking is true if pipelines configured for the previous release could
+	// fail or behave differently with the new release.
+	Breaking bool
+}
+
+// ReleaseChanges contains the parameter changes between a release and the
+// release preceding it.
+type ReleaseChanges struct {
+	Tag         string
+	PreviousTag string
+	Changes     []ParameterChange
+}
+
+// HasBreaking returns true if any of the changes is breaking.
+func (rc ReleaseChanges) HasBreaking() bool {
+	return slices.ContainsFunc(rc.Changes, func(c ParameterChange) bool { return c.Breaking })
+}
+
+// parameterChangelog compares the specifications of consecutive releases.
+// The versions need to be sorted newest first (see sortedVersions), the
+// changelog is returned in the same order.
+func parameterChangelog(versions []versionSpecification) []ReleaseChanges {
+	var changelog []ReleaseChanges
+	for i := 0; i+1 < len(versions); i++ {
+		newer, older := versions[i], versions[i+1]
+		changelog = append(changelog, ReleaseChanges{
+			Tag:         newer.Tag,
+			PreviousTag: older.Tag,
+			Changes:     diffSpecifications(older.Specification, newer.Specification),
+		})
+	}
+	return changelog
+}
+
+// diffSpecifications returns the parameter changes between the old and new
+// specification, source parameters first.
+func diffSpecifications(oldSpec, newSpec Specification) []ParameterChange {
+	params := func(p *PluginSpecification) []Parameter {
+		if p == nil {
+			return nil
+		}
+		return p.Parameters
+	}
+
+	changes := diffParameters("source", params(oldSpec.Source), params(newSpec.Source))
+	return append(changes, diffParameters("destination", params(oldSpec.Destination), params(newSpec.Destination))...)
+}
+
+func diffParameters(plugin string, oldParams, newParams []Parameter) []ParameterChange {
+	oldByName := make(map[string]Parameter, len(oldParams))
+	for _, p := range oldParams {
+		oldByName[p.Name] = p
+	}
+	newByName := make(map[string]Parameter, len(newParams))
+	for _, p := range newParams {
+		newByName[p.Name] = p
+	}
+
+	var changes, added []ParameterChange
+	var removed []Parameter
+	for _, p := range oldParams {
+		if _, ok := newByName[p.Name]; !ok {
+			removed = append(removed, p)
+		}
+	}
+
+	for _, p := range newParams {
+		old, ok := oldByName[p.Name]
+		if !ok {
+			if i := findRenamed(removed, p); i >= 0 {
+				changes = append(changes, ParameterChange{
+					Plugin:    plugin,
+					Parameter: p.Name,
+					Kind:      ChangeRenamed,
+					Details:   fmt.Sprintf("renamed from `%s`", removed[i].Name),
+					Breaking:  true,
+				})
+				changes = append(changes, diffParameter(plugin, removed[i], p)...)
+				removed = slices.Delete(removed, i, i+1)
+				continue
+			}
+			added = append(added, ParameterChange{
+				Plugin:    plugin,
+				Parameter: p.Name,
+				Kind:      ChangeAdded,
+				Details:   addedDetails(p),
+				// New parameters only break existing pipelines if they need
+				// to be configured.
+				Breaking: p.IsRequired() && p.Default == "",
+			})
+			continue
+		}
+		changes = append(changes, diffParameter(plugin, old, p)...)
+	}
+
+	changes = append(changes, added...)
+	for _, p := range removed {
+		changes = append(changes, ParameterChange{
+			Plugin:    plugin,
+			Parameter: p.Name,
+			Kind:      ChangeRemoved,
+			Details:   "removed",
+			Breaking:  true,
+		})
+	}
+	return changes
+}
+
+// findRenamed returns the index of the removed parameter that was most likely
+// renamed to p, or -1 if there is none. A parameter is considered renamed if
+// it has the same type and a non-empty description that didn't change.
+func findRenamed(removed []Parameter, p Parameter) int {
+	if p.Description == "" {
+		return -1
+	}
+	return slices.IndexFunc(removed, func(old Parameter) bool {
+		return old.Type == p.Type && old.Description == p.Description
+	})
+}
+
+func addedDetails(p Parameter) string {
+	var sb strings.Builder
+	sb.WriteString("added")
+	if p.IsRequired() {
+		sb.WriteString(" (required)")
+	}
+	if p.Default != "" {
+		fmt.Fprintf(&sb, " with default %s", formatChangelogValue(p.Default))
+	}
+	return sb.String()
+}
+
+// diffParameter compares two versions of the same parameter.
+func diffParameter(plugin string, oldParam, newParam Parameter) []ParameterChange {
+	var changes []ParameterChange
+	change := func(kind ChangeKind, breaking bool, details string) {
+		changes = append(changes, ParameterChange{
+			Plugin:    plugin,
+			Parameter: newParam.Name,
+			Kind:      kind,
+			Details:   details,
+			Breaking:  breaking,
+		})
+	}
+
+	if oldParam.Type != newParam.Type {
+		change(ChangeTypeChanged, true, fmt.Sprintf("type changed from `%s` to `%s`", oldParam.Type, newParam.Type))
+	}
+	if oldParam.Default != newParam.Default {
+		// Pipelines relying on the old default behave differently.
+		change(ChangeDefaultChanged, true, fmt.Sprintf("default changed from %s to %s",
+			formatChangelogValue(oldParam.Default), formatChangelogValue(newParam.Default)))
+	}
+
+	tightened, relaxed := diffValidations(oldParam.Validations, newParam.Validations)
+	if len(tightened) > 0 {
+		change(ChangeValidationsTightened, true, "validations tightened: "+strings.Join(tightened, ", "))
+	}
+	if len(relaxed) > 0 {
+		change(ChangeValidationsRelaxed, false, "validations relaxed: "+strings.Join(relaxed, ", "))
+	}
+	return changes
+}
+
+// diffValidations compares the validations of two versions of a parameter and
+// returns descriptions of the validations that accept fewer values (tightened)
+// and more values (relaxed) than before.
+func diffValidations(oldValidations, newValidations []Validation) (tightened, relaxed []string) {
+	byType := func(vs []Validation) map[string]Validation {
+		m := make(map[string]Validation, len(vs))
+		for _, v := range vs {
+			m[v.Type] = v
+		}
+		return m
+	}
+	oldByType, newByType := byType(oldValidations), byType(newValidations)
+
+	for _, t := range []config.ValidationType{
+		config.ValidationTypeRequired,
+		config.ValidationTypeGreaterThan,
+		config.ValidationTypeLessThan,
+		config.ValidationTypeInclusion,
+		config.ValidationTypeExclusion,
+		config.ValidationTypeRegex,
+	} {
+		oldV, hadOld := oldByType[t.String()]
+		newV, hasNew := newByType[t.String()]
+		switch {
+		case !hadOld && !hasNew:
+			continue
+		case !hadOld:
+			tightened = append(tightened, "added "+formatValidation(newV))
+			continue
+		case !hasNew:
+			relaxed = append(relaxed, "removed "+formatValidation(oldV))
+			continue
+		case oldV.Value == newV.Value:
+			continue
+		}
+
+		desc := fmt.Sprintf("%s changed from `%s` to `%s`", t, oldV.Value, newV.Value)
+		if isValidationTightened(t, oldV.Value, newV.Value) {
+			tightened = append(tightened, desc)
+		} else {
+			relaxed = append(relaxed, desc)
+		}
+	}
+	return tightened, relaxed
+}
+
+// isValidationTightened returns true if the validation with the new value
+// could reject values accepted with the old value. Changes that can't be
+// compared (e.g. regular expressions) are considered tightened.
+func isValidationTightened(t config.ValidationType, oldValue, newValue string) bool {
+	// isSubset returns true if all items of a are in b.
+	isSubset := func(a, b []string) bool {
+		for _, item := range a {
+			if !slices.Contains(b, item) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch t {
+	case config.ValidationTypeGreaterThan, config.ValidationTypeLessThan:
+		oldV, errOld := strconv.ParseFloat(oldValue, 64)
+		newV, errNew := strconv.ParseFloat(newValue, 64)
+		if errOld != nil || errNew != nil {
+			return true
+		}
+		if t == config.ValidationTypeGreaterThan {
+			return newV > oldV
+		}
+		return newV < oldV
+	case config.ValidationTypeInclusion:
+		// tightened if previously allowed values are no longer included
+		return !isSubset(splitValidationList(oldValue), splitValidationList(newValue))
+	case config.ValidationTypeExclusion:
+		// tightened if new values are excluded
+		return !isSubset(splitValidationList(newValue), splitValidationList(oldValue))
+	default:
+		return true
+	}
+}
+
+func formatValidation(v Validation) string {
+	if v.Value == "" {
+		return v.Type
+	}
+	return fmt.Sprintf("%s `%s`", v.Type, v.Value)
+}
+
+func formatChangelogValue(value string) string {
+	if value == "" {
+		return "no default"
+	}
+	return fmt.Sprintf("`%s`", value)
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSpecifications(t *testing.T) {
+	oldSpec := Specification{
+		Source: &PluginSpecification{Parameters: []Parameter{
+			{Name: "url", Type: "string", Validations: []Validation{{Type: "required"}}},
+			{Name: "table", Type: "string", Description: "Table to read from."},
+			{Name: "fetchSize", Type: "int", Default: "100", Validations: []Validation{{Type: "greater-than", Value: "0"}}},
+			{Name: "mode", Type: "string", Default: "a", Validations: []Validation{{Type: "inclusion", Value: "a,b,c"}}},
+			{Name: "timeout", Type: "string", Default: "1s"},
+			{Name: "legacy", Type: "bool"},
+		}},
+	}
+	newSpec := Specification{
+		Source: &PluginSpecification{Parameters: []Parameter{
+			{Name: "url", Type: "string"},
+			{Name: "tables", Type: "string", Description: "Table to read from."},
+			{Name: "fetchSize", Type: "int", Default: "1000", Validations: []Validation{{Type: "greater-than", Value: "10"}}},
+			{Name: "mode", Type: "string", Default: "a", Validations: []Validation{{Type: "inclusion", Value: "a,b"}}},
+			{Name: "timeout", Type: "duration", Default: "1s"},
+			{Name: "token", Type: "string", Validations: []Validation{{Type: "required"}}},
+			{Name: "verbose", Type: "bool", Default: "false"},
+		}},
+		Destination: &PluginSpecification{Parameters: []Parameter{
+			{Name: "batch", Type: "int"},
+		}},
+	}
+
+	want := []ParameterChange{
+		{Plugin: "source", Parameter: "url", Kind: ChangeValidationsRelaxed, Details: "validations relaxed: removed required"},
+		{Plugin: "source", Parameter: "tables", Kind: ChangeRenamed, Details: "renamed from `table`", Breaking: true},
+		{Plugin: "source", Parameter: "fetchSize", Kind: ChangeDefaultChanged, Details: "default changed from `100` to `1000`", Breaking: true},
+		{Plugin: "source", Parameter: "fetchSize", Kind: ChangeValidationsTightened, Details: "validations tightened: greater-than changed from `0` to `10`", Breaking: true},
+		{Plugin: "source", Parameter: "mode", Kind: ChangeValidationsTightened, Details: "validations tightened: inclusion changed from `a,b,c` to `a,b`", Breaking: true},
+		{Plugin: "source", Parameter: "timeout", Kind: ChangeTypeChanged, Details: "type changed from `string` to `duration`", Breaking: true},
+		{Plugin: "source", Parameter: "token", Kind: ChangeAdded, Details: "added (required)", Breaking: true},
+		{Plugin: "source", Parameter: "verbose", Kind: ChangeAdded, Details: "added with default `false`"},
+		{Plugin: "source", Parameter: "legacy", Kind: ChangeRemoved, Details: "removed", Breaking: true},
+		{Plugin: "destination", Parameter: "batch", Kind: ChangeAdded, Details: "added"},
+	}
+
+	got := diffSpecifications(oldSpec, newSpec)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected changes\nwant: %+v\ngot:  %+v", want, got)
+	}
+}
+
+func TestIsValidationTightened(t *testing.T) {
+	tests := []struct {
+		validation string
+		oldValue   string
+		newValue   string
+		want       bool
+	}{
+		{validation: "greater-than", oldValue: "0", newValue: "1", want: true},
+		{validation: "greater-than", oldValue: "1", newValue: "0", want: false},
+		{validation: "less-than", oldValue: "10", newValue: "5", want: true},
+		{validation: "less-than", oldValue: "5", newValue: "10", want: false},
+		{validation: "inclusion", oldValue: "a,b", newValue: "a, b, c", want: false},
+		{validation: "inclusion", oldValue: "a,b", newValue: "a,c", want: true},
+		{validation: "exclusion", oldValue: "a", newValue: "a,b", want: true},
+		{validation: "exclusion", oldValue: "a,b", newValue: "a", want: false},
+		{validation: "regex", oldValue: ".*", newValue: "^a$", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.validation+" "+tt.oldValue+" to "+tt.newValue, func(t *testing.T) {
+			vt, err := (Validation{Type: tt.validation, Value: tt.newValue}).toConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := isValidationTightened(vt.Type(), tt.oldValue, tt.newValue); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -19,33 +19,90 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
+	"time"
 
-	"github.com/conduitio/conduit-connector-sdk/conn-sdk-cli/readmegen"
-	"github.com/conduitio/yaml/v3"
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/conduitio/genfuncs"
+	"gopkg.in/yaml.v3"
 )
 
 type CommandDocs struct {
 	connectorsFile string
 	specsFolder    string
-	outputFolder   string
+	pageIndexFile  string
+	targets        []pageTarget
+
+	trustConfig trustConfig
 }
 
-func NewCommandDocs(connectorsFile, specsFolder, outputFolder string) *CommandDocs {
+func NewCommandDocs(connectorsFile, specsFolder, pageIndexFile string, targets []pageTarget) *CommandDocs {
 	return &CommandDocs{
 		connectorsFile: connectorsFile,
 		specsFolder:    specsFolder,
-		outputFolder:   outputFolder,
+		pageIndexFile:  pageIndexFile,
+		targets:        targets,
 	}
 }
 
 type data struct {
 	Repository
-	Specifications map[string]any
+	// SidebarPosition is the position of the page in the sidebar.
+	SidebarPosition int
+	// Specifications contains the specification of each release keyed by tag,
+	// the specification of the latest release is additionally stored under
+	// the key "latest".
+	Specifications map[string]Specification
+	// Versions contains the specification of each release, newest first.
+	Versions []versionSpecification
+	// Changelog contains the parameter changes between consecutive releases,
+	// newest first.
+	Changelog []ReleaseChanges
+	// LatestDownloads contains the assets of the latest release, nil if there
+	// is no latest release.
+	LatestDownloads *releaseDownloads
+	// PreviousDownloads contains the assets of the other releases, newest
+	// first.
+	PreviousDownloads []releaseDownloads
+	// Readme contains the README of the latest release, sanitised so it can be
+	// embedded in the page. It is empty if the README was not fetched.
+	Readme string
+}
+
+// versionSpecification is the specification of a single connector release.
+type versionSpecification struct {
+	Tag           string
+	Latest        bool
+	Specification Specification
+}
+
+// sortedVersions returns the specifications of all releases, sorted by
+// semantic version with the newest release first. Tags that are not valid
+// semantic versions are sorted last.
+func sortedVersions(repo Repository, specifications map[string]Specification) []versionSpecification {
+	var versions []versionSpecification
+	for _, release := range repo.Releases {
+		spec, ok := specifications[release.TagName]
+		if !ok {
+			continue
+		}
+		versions = append(versions, versionSpecification{
+			Tag:           release.TagName,
+			Latest:        release.IsLatest,
+			Specification: spec,
+		})
+	}
+
+	slices.SortStableFunc(versions, func(a, b versionSpecification) int {
+		return genfuncs.CompareVersions(b.Tag, a.Tag)
+	})
+	return versions
 }
 
 func (cmd *CommandDocs) Execute(ctx context.Context) error {
@@ -62,13 +119,31 @@ func (cmd *CommandDocs) Execute(ctx context.Context) error {
 		return fmt.Errorf("failed to parse JSON input: %w", err)
 	}
 
-	// Create output folder if it doesn't exist
-	if err := os.MkdirAll(cmd.outputFolder, 0755); err != nil {
-		return fmt.Errorf("failed to create output folder: %w", err)
+	funcs := templateFuncs(cmd.referenceTime(repositories))
+	templates := make([]executor, len(cmd.targets))
+	for i, target := range cmd.targets {
+		// Create output folder if it doesn't exist
+		if err := os.MkdirAll(target.Folder, 0755); err != nil {
+			return fmt.Errorf("failed to create output folder: %w", err)
+		}
+		if templates[i], err = target.parse(funcs); err != nil {
+			return err
+		}
+	}
+
+	previousIndex, err := readPageIndex(cmd.pageIndexFile)
+	if err != nil {
+		return err
+	}
+
+	cmd.trustConfig, err = parseTrustConfig(trustConfigYaml)
+	if err != nil {
+		return err
 	}
 
 	// Process each repository
-	for i, repo := range repositories {
+	var pages []connectorPage
+	for _, repo := range repositories {
 		fmt.Printf("\n🕵  Processing repository %v\n", repo.NameWithOwner)
 
 		specifications := cmd.loadSpecifications(repo)
@@ -82,37 +157,42 @@ func (cmd *CommandDocs) Execute(ctx context.Context) error {
 			continue
 		}
 
-		err := cmd.generateDocPage(i, connectorName, repo, specifications)
-		if err != nil {
-			return fmt.Errorf("failed to generate documentation for %v: %w", repo.NameWithOwner, err)
+		// classify again, so changes to trust-config.yaml don't require
+		// fetching the registry
+		repo.Trust = cmd.trustConfig.classify(repo)
+
+		pages = append(pages, connectorPage{
+			Repository:     repo,
+			Name:           connectorName,
+			Specifications: specifications,
+		})
+	}
+
+	fmt.Printf("\n🔗 Assigning page slugs ...\n")
+	index := previousIndex.assignSlugs(pages)
+
+	for _, page := range pages {
+		for i, target := range cmd.targets {
+			if err := cmd.generateDocPage(page, target, templates[i]); err != nil {
+				return fmt.Errorf("failed to generate %s documentation for %v: %w", target.Name, page.Repository.NameWithOwner, err)
+			}
 		}
+	}
 
-		fmt.Println("  ✅ Documentation generated")
+	fmt.Printf("\n💾 Saving page index to %s ...\n", cmd.pageIndexFile)
+	if err := index.write(cmd.pageIndexFile); err != nil {
+		return err
 	}
+	fmt.Printf("✅ Generated %d pages, %d redirects\n", len(pages), len(index.Redirects))
 
 	return nil
 }
 
-func getConnectorName(specifications map[string]any) string {
-	if len(specifications) == 0 {
-		return ""
-	}
-	latestSpecs, ok := specifications["latest"].(map[string]any)
-	if !ok {
-		return ""
-	}
-	spec, ok := latestSpecs["specification"].(map[string]any)
-	if !ok {
-		return ""
-	}
-	name, ok := spec["name"].(string)
-	if !ok {
-		return ""
-	}
-	return name
+func getConnectorName(specifications map[string]Specification) string {
+	return specifications["latest"].Name
 }
 
-func (cmd *CommandDocs) loadSpecifications(repo Repository) map[string]any {
+func (cmd *CommandDocs) loadSpecifications(repo Repository) map[string]Specification {
 	// Split owner and repo name
 	owner, repoName, found := strings.Cut(repo.NameWithOwner, "/")
 	if !found {
@@ -120,16 +200,13 @@ func (cmd *CommandDocs) loadSpecifications(repo Repository) map[string]any {
 		return nil
 	}
 
-	specifications := map[string]any{}
+	specifications := map[string]Specification{}
 
 	for _, release := range repo.Releases {
-		folderPath := filepath.Join(cmd.specsFolder, "github.com", owner, repoName+"@"+release.TagName)
-		connectorYamlPath := filepath.Join(folderPath, "connector.yaml")
-
-		specs, err := cmd.readSpecs(connectorYamlPath)
+		specs, err := cmd.loadSpecification(repo, release.TagName)
 		if err != nil {
-			fmt.Printf("  ⚠️  Warning: could not load connector.yaml for %s@%s\n",
-				owner+"/"+repoName, release.TagName)
+			fmt.Printf("  ⚠️  Warning: could not load connector.yaml for %s@%s: %v\n",
+				owner+"/"+repoName, release.TagName, err)
 			continue
 		}
 
@@ -142,27 +219,79 @@ func (cmd *CommandDocs) loadSpecifications(repo Repository) map[string]any {
 	return specifications
 }
 
-func (*CommandDocs) readSpecs(path string) (map[string]any, error) {
-	specsRaw, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read specifications from %v: %w", path, err)
+// referenceTime returns the time relative dates on the pages are based on,
+// the most recent time a specification was fetched. The pages only change when
+// the specifications do, instead of on every run. If no specification was
+// fetched, the most recent release is used.
+func (cmd *CommandDocs) referenceTime(repositories []Repository) time.Time {
+	var latest, published time.Time
+	for _, repo := range repositories {
+		owner, repoName, _ := strings.Cut(repo.NameWithOwner, "/")
+		for _, release := range repo.Releases {
+			if release.PublishedAt.After(published) {
+				published = release.PublishedAt
+			}
+			raw, err := os.ReadFile(filepath.Join(cmd.specsFolder, "github.com", owner, repoName+"@"+release.TagName, ".metadata.yaml"))
+			if err != nil {
+				continue
+			}
+			var md Metadata
+			if err := yaml.Unmarshal(raw, &md); err != nil {
+				fmt.Printf("  ⚠️  Warning: could not parse .metadata.yaml of %s@%s: %v\n", repo.NameWithOwner, release.TagName, err)
+				continue
+			}
+			if md.FetchedAt.After(latest) {
+				latest = md.FetchedAt
+			}
+		}
+	}
+	if latest.IsZero() {
+		return published
+	}
+	return latest
+}
+
+// loadSpecification loads the specification of a single release of the
+// repository.
+func (cmd *CommandDocs) loadSpecification(repo Repository, tag string) (Specification, error) {
+	owner, repoName, _ := strings.Cut(repo.NameWithOwner, "/")
+	path := filepath.Join(cmd.specsFolder, "github.com", owner, repoName+"@"+tag, "connector.yaml")
+	return cmd.readSpecs(path)
+}
+
+// loadReadme returns the sanitised README of the latest release, or an empty
+// string if there is none.
+func (cmd *CommandDocs) loadReadme(repo Repository) string {
+	idx := slices.IndexFunc(repo.Releases, func(r Release) bool { return r.IsLatest })
+	if idx == -1 {
+		return ""
 	}
+	tag := repo.Releases[idx].TagName
 
-	var data map[string]any
-	err = yaml.Unmarshal(specsRaw, &data)
+	owner, repoName, _ := strings.Cut(repo.NameWithOwner, "/")
+	path := filepath.Join(cmd.specsFolder, "github.com", owner, repoName+"@"+tag, "README.md")
+	readme, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ""
+	} else if err != nil {
+		fmt.Printf("  ⚠️  Warning: could not load README for %s@%s: %v\n", repo.NameWithOwner, tag, err)
+		return ""
+	}
+
+	return sanitizeReadme(string(readme), repo.NameWithOwner, tag)
+}
+
+func (*CommandDocs) readSpecs(path string) (Specification, error) {
+	specsRaw, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal specifications: %w", err)
+		return Specification{}, fmt.Errorf("failed to read specifications from %v: %w", path, err)
 	}
-	return data, nil
+
+	return decodeConnectorYAML(specsRaw)
 }
 
-func (cmd *CommandDocs) generateDocPage(
-	index int,
-	connectorName string,
-	repo Repository,
-	specifications map[string]any,
-) error {
-	path := filepath.Join(cmd.outputFolder, fmt.Sprintf("%v-%v.mdx", (index+1), connectorName))
+func (cmd *CommandDocs) generateDocPage(page connectorPage, target pageTarget, tmpl executor) error {
+	path := filepath.Join(target.Folder, page.Slug+target.Extension)
 	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		return fmt.Errorf("could not open file %s: %w", path, err)
@@ -173,14 +302,18 @@ func (cmd *CommandDocs) generateDocPage(
 	// Generate into a buffer so we can rewrite legacy conduit.io links (sourced
 	// from upstream connector READMEs) to the current site domain before writing.
 	var buf bytes.Buffer
-	if err := readmegen.Generate(readmegen.GenerateOptions{
-		Data: data{
-			Repository:     repo,
-			Specifications: specifications,
-		},
-		ReadmePath: "./connector-docs-mdx.tmpl",
-		Out:        &buf,
-		FuncMap:    funcMap,
+	versions := sortedVersions(page.Repository, page.Specifications)
+	latestDownloads, previousDownloads := connectorDownloads(page.Repository, page.Specifications)
+	if err := tmpl.Execute(&buf, data{
+		Repository:      page.Repository,
+		SidebarPosition: page.Position,
+		Specifications:  page.Specifications,
+		Versions:        versions,
+		Changelog:       parameterChangelog(versions),
+		Readme:          cmd.loadReadme(page.Repository),
+
+		LatestDownloads:   latestDownloads,
+		PreviousDownloads: previousDownloads,
 	}); err != nil {
 		return err
 	}
@@ -191,7 +324,67 @@ func (cmd *CommandDocs) generateDocPage(
 }
 
 var funcMap = template.FuncMap{
+	"args":                      args,
+	"examplePipeline":           examplePipeline,
+	"markdownCell":              markdownCell,
 	"formatParameterValueTable": formatParameterValueTable,
+	"parameterTable":            parameterTable,
+	"connectorParameters":       connectorParameters,
+	"sdkParameters":             sdkParameters,
+	"mdx":                       genfuncs.EscapeMDX,
+	"frontMatter":               genfuncs.FrontMatterString,
+}
+
+// parameterTableRow is a row of the ParameterTable component, see
+// src/components/ParameterTable.
+type parameterTableRow struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default"`
+	Description string   `json:"description"`
+	Validations []string `json:"validations"`
+}
+
+// parameterTable returns the parameters as a JSON array that can be passed
+// to the ParameterTable component. JSON is a valid JavaScript expression, so
+// the values don't need to be escaped for MDX.
+func parameterTable(params []Parameter) (string, error) {
+	rows := make([]parameterTableRow, len(params))
+	for i, p := range params {
+		rows[i] = parameterTableRow{
+			Name:        p.Name,
+			Type:        p.Type,
+			Required:    p.IsRequired(),
+			Default:     p.Default,
+			Description: p.Description,
+			Validations: []string{},
+		}
+		for _, v := range p.Validations {
+			// required is shown in a separate column
+			if v.Type == config.ValidationTypeRequired.String() {
+				continue
+			}
+			rows[i].Validations = append(rows[i].Validations, v.Describe())
+		}
+	}
+
+	raw, err := json.Marshal(rows)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal parameter table: %w", err)
+	}
+	return string(raw), nil
+}
+
+// connectorParameters returns the parameters that are not provided by the
+// SDK.
+func connectorParameters(params []Parameter) []Parameter {
+	return slices.DeleteFunc(slices.Clone(params), func(p Parameter) bool { return isSDKParameter(p.Name) })
+}
+
+// sdkParameters returns the parameters provided by the SDK (sdk.*).
+func sdkParameters(params []Parameter) []Parameter {
+	return slices.DeleteFunc(slices.Clone(params), func(p Parameter) bool { return !isSDKParameter(p.Name) })
 }
 
 // formatParameterValue formats the value of a configuration parameter.
@@ -0,0 +1,126 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSortedVersions(t *testing.T) {
+	repo := Repository{
+		Releases: []Release{
+			{TagName: "v0.9.0"},
+			{TagName: "v0.10.0", IsLatest: true},
+			{TagName: "nightly"},
+			{TagName: "v0.2.1"},
+			{TagName: "v0.3.0"}, // no specification
+		},
+	}
+	specs := map[string]Specification{
+		"v0.9.0":  {},
+		"v0.10.0": {},
+		"nightly": {},
+		"v0.2.1":  {},
+	}
+
+	got := sortedVersions(repo, specs)
+
+	var tags []string
+	for _, v := range got {
+		tags = append(tags, v.Tag)
+	}
+	want := []string{"v0.10.0", "v0.9.0", "v0.2.1", "nightly"}
+	if !slices.Equal(tags, want) {
+		t.Fatalf("expected tags %v, got %v", want, tags)
+	}
+	if !got[0].Latest || got[1].Latest {
+		t.Fatalf("expected only v0.10.0 to be latest")
+	}
+}
+
+func TestParameterTable(t *testing.T) {
+	params := []Parameter{
+		{Name: "url", Type: "string", Description: "URL of <the> {server}.", Validations: []Validation{{Type: "required"}}},
+		{Name: "sdk.batch.size", Type: "int", Default: "0", Validations: []Validation{{Type: "greater-than", Value: "-1"}}},
+	}
+
+	got, err := parameterTable(connectorParameters(params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"name":"url","type":"string","required":true,"default":"","description":"URL of \u003cthe\u003e {server}.","validations":[]}]`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	got, err = parameterTable(sdkParameters(params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `[{"name":"sdk.batch.size","type":"int","required":false,"default":"0","description":"","validations":["greater than -1"]}]`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestReferenceTime(t *testing.T) {
+	published := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	repos := []Repository{{
+		NameWithOwner: "acme/conduit-connector-foo",
+		Releases: []Release{
+			{TagName: "v0.1.0", PublishedAt: published.Add(-24 * time.Hour)},
+			{TagName: "v0.2.0", PublishedAt: published},
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string // tag -> .metadata.yaml
+		want     time.Time
+	}{{
+		name: "latest fetched",
+		metadata: map[string]string{
+			"v0.1.0": "fetchedAt: 2025-04-01T10:00:00Z\n",
+			"v0.2.0": "fetchedAt: 2025-05-01T10:00:00Z\n",
+		},
+		want: time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC),
+	}, {
+		name: "no metadata",
+		want: published,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for tag, md := range tt.metadata {
+				folder := filepath.Join(dir, "github.com", "acme", "conduit-connector-foo@"+tag)
+				if err := os.MkdirAll(folder, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(folder, ".metadata.yaml"), []byte(md), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd := &CommandDocs{specsFolder: dir}
+			if got := cmd.referenceTime(repos); !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// osOrder and archOrder define the order of the rows and columns in the
+// download matrix, values not listed are sorted alphabetically after them.
+var (
+	osOrder   = []string{"linux", "darwin", "windows"}
+	archOrder = []string{"amd64", "arm64", "386", "arm"}
+)
+
+// releaseDownloads contains the assets of a release arranged as an OS × arch
+// matrix.
+type releaseDownloads struct {
+	Release Release
+	// Install is the argument for `conduit connectors install`.
+	Install string
+	Archs   []string
+	Rows    []downloadRow
+}
+
+type downloadRow struct {
+	OS string
+	// Assets contains an asset for each architecture in Archs, nil if there
+	// is no asset for the combination.
+	Assets []*Asset
+}
+
+// connectorDownloads returns the downloads of the latest release and of all
+// other releases, the latter sorted by publish date with the newest first.
+// Draft releases are skipped.
+func connectorDownloads(repo Repository, specifications map[string]Specification) (*releaseDownloads, []releaseDownloads) {
+	name := specifications["latest"].Name
+
+	var latest *releaseDownloads
+	var previous []releaseDownloads
+	for _, release := range repo.Releases {
+		if release.Draft {
+			continue
+		}
+		d := newReleaseDownloads(name, release)
+		if release.IsLatest {
+			latest = &d
+			continue
+		}
+		previous = append(previous, d)
+	}
+
+	slices.SortStableFunc(previous, func(a, b releaseDownloads) int {
+		return b.Release.PublishedAt.Compare(a.Release.PublishedAt)
+	})
+	return latest, previous
+}
+
+func newReleaseDownloads(connectorName string, release Release) releaseDownloads {
+	var osList, archList []string
+	for _, asset := range release.Assets {
+		if !slices.Contains(osList, asset.OS) {
+			osList = append(osList, asset.OS)
+		}
+		if !slices.Contains(archList, asset.Arch) {
+			archList = append(archList, asset.Arch)
+		}
+	}
+	slices.SortFunc(osList, orderedCompare(osOrder))
+	slices.SortFunc(archList, orderedCompare(archOrder))
+
+	rows := make([]downloadRow, len(osList))
+	for i, os := range osList {
+		rows[i] = downloadRow{OS: os, Assets: make([]*Asset, len(archList))}
+		for j, arch := range archList {
+			idx := slices.IndexFunc(release.Assets, func(a Asset) bool { return a.OS == os && a.Arch == arch })
+			if idx != -1 {
+				rows[i].Assets[j] = &release.Assets[idx]
+			}
+		}
+	}
+
+	return releaseDownloads{
+		Release: release,
+		Install: connectorName + "@" + release.TagName,
+		Archs:   archList,
+		Rows:    rows,
+	}
+}
+
+// orderedCompare returns a compare function that sorts the values in order
+// first, followed by all other values sorted alphabetically.
+func orderedCompare(order []string) func(a, b string) int {
+	rank := func(s string) int {
+		if i := slices.Index(order, s); i != -1 {
+			return i
+		}
+		return len(order)
+	}
+	return func(a, b string) int {
+		if ra, rb := rank(a), rank(b); ra != rb {
+			return ra - rb
+		}
+		return strings.Compare(a, b)
+	}
+}
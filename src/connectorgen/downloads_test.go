@@ -0,0 +1,81 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestConnectorDownloads(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	repo := Repository{
+		Releases: []Release{
+			{TagName: "v0.1.0", PublishedAt: day(1)},
+			{TagName: "v0.3.0", PublishedAt: day(3), Draft: true},
+			{TagName: "v0.2.1", PublishedAt: day(4), IsLatest: true, Assets: []Asset{
+				{Name: "w", OS: "windows", Arch: "amd64"},
+				{Name: "f", OS: "freebsd", Arch: "arm64"},
+				{Name: "l", OS: "linux", Arch: "amd64"},
+				{Name: "la", OS: "linux", Arch: "arm64"},
+			}},
+			{TagName: "v0.2.0", PublishedAt: day(2)},
+		},
+	}
+	specs := map[string]Specification{"latest": {Name: "foo"}}
+
+	latest, previous := connectorDownloads(repo, specs)
+
+	if latest == nil || latest.Release.TagName != "v0.2.1" {
+		t.Fatalf("expected latest release v0.2.1, got %+v", latest)
+	}
+	if latest.Install != "foo@v0.2.1" {
+		t.Fatalf("unexpected install argument %q", latest.Install)
+	}
+	if got, want := latest.Archs, []string{"amd64", "arm64"}; !slices.Equal(got, want) {
+		t.Fatalf("expected archs %v, got %v", want, got)
+	}
+
+	wantRows := map[string][]string{
+		"linux":   {"l", "la"},
+		"windows": {"w", ""},
+		"freebsd": {"", "f"},
+	}
+	var gotOS []string
+	for _, row := range latest.Rows {
+		gotOS = append(gotOS, row.OS)
+		for i, asset := range row.Assets {
+			name := ""
+			if asset != nil {
+				name = asset.Name
+			}
+			if name != wantRows[row.OS][i] {
+				t.Errorf("%s/%s: expected asset %q, got %q", row.OS, latest.Archs[i], wantRows[row.OS][i], name)
+			}
+		}
+	}
+	if want := []string{"linux", "windows", "freebsd"}; !slices.Equal(gotOS, want) {
+		t.Fatalf("expected OS rows %v, got %v", want, gotOS)
+	}
+
+	var tags []string
+	for _, d := range previous {
+		tags = append(tags, d.Release.TagName)
+	}
+	if want := []string{"v0.2.0", "v0.1.0"}; !slices.Equal(tags, want) {
+		t.Fatalf("expected previous releases %v, got %v", want, tags)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/conduitio/genfuncs"
+	"gopkg.in/yaml.v3"
+)
+
+// examplePipeline returns an example pipeline configuration containing a
+// connector with all parameters of the plugin set to their default values.
+// The description and type of each parameter is written as a comment above
+// it. The configuration is parsed again to make sure it's valid YAML that
+// contains the same values.
+func examplePipeline(reference, pluginType string, params []Parameter) (string, error) {
+	var settings pipelineSettings
+	for _, p := range params {
+		settings = append(settings, pipelineSetting{
+			Key:     p.Name,
+			Value:   p.Default,
+			Comment: genfuncs.ExampleComment(p.Description, p.Type),
+		})
+	}
+
+	cfg := pipelineConfig{
+		Version: "2.2",
+		Pipelines: []pipelineConfigPipeline{{
+			ID:     "example",
+			Status: "running",
+			Connectors: []pipelineConfigConnector{{
+				ID:       "example-" + pluginType,
+				Type:     pluginType,
+				Plugin:   reference,
+				Name:     "example-" + pluginType,
+				Settings: settings,
+			}},
+		}},
+	}
+
+	var out any = cfg
+	if len(settings) == 0 {
+		// Encoding into a node drops the comments of the settings, it's only
+		// done if there are none to note that below the connector.
+		var doc yaml.Node
+		if err := doc.Encode(cfg); err != nil {
+			return "", fmt.Errorf("failed to encode example pipeline: %w", err)
+		}
+		connector := mappingValue(mappingValue(&doc, "pipelines").Content[0], "connectors").Content[0]
+		connector.Content[len(connector.Content)-2].FootComment = "No parameters"
+		out = &doc
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(out); err != nil {
+		return "", fmt.Errorf("failed to marshal example pipeline: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("failed to marshal example pipeline: %w", err)
+	}
+
+	if err := checkExamplePipeline(buf.Bytes(), cfg); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// checkExamplePipeline parses the generated example pipeline and checks that
+// it contains the connectors and settings of the original configuration.
+func checkExamplePipeline(raw []byte, want pipelineConfig) error {
+	got, err := parsePipelineConfig(raw)
+	if err != nil {
+		return fmt.Errorf("generated example pipeline is invalid: %w\n%s", err, raw)
+	}
+
+	equal := got.Version == want.Version && len(got.Pipelines) == len(want.Pipelines)
+	for i := 0; equal && i < len(got.Pipelines); i++ {
+		gotConnectors, wantConnectors := got.Pipelines[i].Connectors, want.Pipelines[i].Connectors
+		equal = len(gotConnectors) == len(wantConnectors)
+		for j := 0; equal && j < len(gotConnectors); j++ {
+			g, w := gotConnectors[j], wantConnectors[j]
+			equal = g.ID == w.ID && g.Type == w.Type && g.Plugin == w.Plugin &&
+				slices.EqualFunc(g.Settings, w.Settings, func(a, b pipelineSetting) bool {
+					return a.Key == b.Key && a.Value == b.Value
+				})
+		}
+	}
+	if !equal {
+		return fmt.Errorf("generated example pipeline does not round-trip:\n%s", raw)
+	}
+	return nil
+}
+
+// mappingValue returns the value of a key in a YAML mapping, documents are
+// unwrapped.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind == yaml.DocumentNode {
+		n = n.Content[0]
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
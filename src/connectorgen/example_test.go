@@ -0,0 +1,97 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExamplePipeline(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params []Parameter
+		want   []string
+	}{{
+		name: "no parameters",
+		want: []string{
+			"      - id: example-source\n        type: source\n        plugin: github.com/demo/conduit-connector-demo@v1.0.0\n        name: example-source\n        # No parameters",
+		},
+	}, {
+		name: "quoted and multiline values",
+		params: []Parameter{{
+			Name:        "table",
+			Type:        "string",
+			Default:     `{{ index .Metadata "opencdc.collection" }}`,
+			Description: "Table name.\n\nSupports templates.",
+		}, {
+			Name:    "query",
+			Type:    "string",
+			Default: "SELECT *\nFROM users",
+		}},
+		want: []string{
+			"        settings:\n          # Table name.\n          # Supports templates.\n          # Type: string\n",
+			`          table: "{{ index .Metadata \"opencdc.collection\" }}"`,
+			"          # Type: string\n          query: |-\n            SELECT *\n            FROM users",
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := examplePipeline("github.com/demo/conduit-connector-demo@v1.0.0", "source", tc.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(got, "version: 2.2\npipelines:\n  - id: example\n    status: running\n") {
+				t.Errorf("unexpected header:\n%s", got)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected example to contain:\n%s\ngot:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckExamplePipeline(t *testing.T) {
+	want := pipelineConfig{
+		Version: "2.2",
+		Pipelines: []pipelineConfigPipeline{{
+			ID: "example",
+			Connectors: []pipelineConfigConnector{{
+				ID:       "example-source",
+				Type:     "source",
+				Plugin:   "demo",
+				Settings: pipelineSettings{{Key: "table", Value: `a "b"`}},
+			}},
+		}},
+	}
+
+	raw := "version: 2.2\npipelines:\n  - id: example\n    connectors:\n      - id: example-source\n        type: source\n        plugin: demo\n        settings:\n          table: \"a \"b\"\"\n"
+	if err := checkExamplePipeline([]byte(raw), want); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+
+	raw = strings.Replace(raw, `"a "b""`, `"a"`, 1)
+	if err := checkExamplePipeline([]byte(raw), want); err == nil || !strings.Contains(err.Error(), "does not round-trip") {
+		t.Errorf("expected round-trip error, got %v", err)
+	}
+
+	raw = strings.Replace(raw, `"a"`, `'a "b"'`, 1)
+	if err := checkExamplePipeline([]byte(raw), want); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/conduitio/genfuncs"
+)
+
+// CommandLint checks the generated connector pages for MDX syntax errors and
+// reports the connector and specification field causing them, so broken
+// pages are caught before the site is built.
+type CommandLint struct {
+	connectorsFile string
+	specsFolder    string
+	pagesFolder    string
+	pageIndexFile  string
+}
+
+func NewCommandLint(connectorsFile, specsFolder, pagesFolder, pageIndexFile string) *CommandLint {
+	return &CommandLint{
+		connectorsFile: connectorsFile,
+		specsFolder:    specsFolder,
+		pagesFolder:    pagesFolder,
+		pageIndexFile:  pageIndexFile,
+	}
+}
+
+// specField is a string field of a specification, as it is rendered in the
+// page.
+type specField struct {
+	path  string
+	value string
+}
+
+func (cmd *CommandLint) Execute(context.Context) error {
+	fmt.Printf("👀 Reading %s ...\n", cmd.connectorsFile)
+
+	data, err := os.ReadFile(cmd.connectorsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	var repositories []Repository
+	if err := json.Unmarshal(data, &repositories); err != nil {
+		return fmt.Errorf("failed to parse JSON input: %w", err)
+	}
+
+	index, err := readPageIndex(cmd.pageIndexFile)
+	if err != nil {
+		return err
+	}
+
+	// loading specifications and READMEs works the same as for pages
+	docs := NewCommandDocs(cmd.connectorsFile, cmd.specsFolder, cmd.pageIndexFile, nil)
+
+	var linted, invalid int
+	for _, repo := range repositories {
+		slug, ok := index.Pages[repo.NameWithOwner]
+		if !ok {
+			continue
+		}
+		path := filepath.Join(cmd.pagesFolder, slug+".mdx")
+		page, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("⚠️  Warning: page %s of %s does not exist\n", path, repo.NameWithOwner)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		linted++
+		problems := genfuncs.LintMDX(string(page))
+		if len(problems) == 0 {
+			continue
+		}
+
+		invalid++
+		fields := cmd.fields(docs, repo)
+		lines := strings.Split(string(page), "\n")
+		fmt.Printf("\n❌ %s (%s)\n", repo.NameWithOwner, path)
+		for _, p := range problems {
+			fmt.Printf("  - %v (%s)\n", p, locateField(fields, lines[p.Line-1], p.Column))
+		}
+	}
+
+	fmt.Printf("\n📊 Summary: %d pages linted, %d with problems\n", linted, invalid)
+	if invalid > 0 {
+		return fmt.Errorf("found problems in %d page(s)", invalid)
+	}
+	return nil
+}
+
+// fields returns all specification fields and the README of the repository as
+// they are rendered in the page.
+func (cmd *CommandLint) fields(docs *CommandDocs, repo Repository) []specField {
+	specifications := docs.loadSpecifications(repo)
+
+	var fields []specField
+	for _, v := range sortedVersions(repo, specifications) {
+		spec := v.Specification
+		add := func(path, value string) {
+			fields = append(fields, specField{
+				path:  fmt.Sprintf("%s of %s", path, v.Tag),
+				value: genfuncs.EscapeMDX(value),
+			})
+		}
+
+		add("specification.name", spec.Name)
+		add("specification.summary", spec.Summary)
+		add("specification.description", spec.Description)
+		add("specification.author", spec.Author)
+		for _, plugin := range []struct {
+			name string
+			spec *PluginSpecification
+		}{{"source", spec.Source}, {"destination", spec.Destination}} {
+			if plugin.spec == nil {
+				continue
+			}
+			for _, p := range plugin.spec.Parameters {
+				add(fmt.Sprintf("specification.%s.parameters[%s].description", plugin.name, p.Name), p.Description)
+			}
+		}
+	}
+	if readme := docs.loadReadme(repo); readme != "" {
+		fields = append(fields, specField{path: "README.md", value: readme})
+	}
+	return fields
+}
+
+// locateField returns a description of the field that contains the text at
+// the given column of the line.
+func locateField(fields []specField, line string, column int) string {
+	// Search for the text around the problem, then for the text starting at
+	// the problem, in case the field starts at the problem.
+	idx := min(max(column-1, 0), len(line))
+	for _, snippet := range []string{
+		line[max(idx-20, 0):min(idx+20, len(line))],
+		line[idx:min(idx+10, len(line))],
+	} {
+		snippet = strings.TrimSpace(snippet)
+		if snippet == "" {
+			continue
+		}
+		for _, f := range fields {
+			if strings.Contains(f.value, snippet) {
+				return "in " + f.path
+			}
+		}
+	}
+	return "not found in the specification, check the template"
+}
@@ -0,0 +1,34 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+)
+
+func TestLocateField(t *testing.T) {
+	fields := []specField{
+		{path: "specification.summary of v1.0.0", value: "A connector."},
+		{path: "specification.description of v1.0.0", value: "Reads <foo from somewhere."},
+	}
+	line := "Reads <foo from somewhere."
+
+	if got, want := locateField(fields, line, 7), "in specification.description of v1.0.0"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got, want := locateField(fields, "<Tabs", 1), "not found in the specification, check the template"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
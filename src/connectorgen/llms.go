@@ -0,0 +1,252 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/llms.txt.tmpl
+var llmsTemplate string
+
+// siteURL is the URL of the documentation site, links in the llms.txt files
+// are absolute, since the files are read outside of the site.
+const siteURL = "https://" + currentDomain
+
+const (
+	// llmsFile is the concise index of all connectors.
+	llmsFile = "connectors.txt"
+	// llmsFullFile contains all connectors including their parameters.
+	llmsFullFile = "connectors-full.txt"
+)
+
+// llmsConnector is a connector listed in the llms.txt files.
+type llmsConnector struct {
+	catalogEntry
+	// Specified is false if there is no specification of the connector.
+	Specified     bool
+	Specification Specification
+}
+
+// llmsData is the input of the llms.txt templates.
+type llmsData struct {
+	Categories []catalogSection
+	Connectors []llmsConnector
+}
+
+// CommandLLMs generates the connector sections of llms.txt and llms-full.txt,
+// Markdown files describing all connectors for AI agents. The output is
+// deterministic, so it can be checked for drift.
+type CommandLLMs struct {
+	connectorsFile string
+	specsFolder    string
+	pageIndexFile  string
+	outputFolder   string
+	// check only compares the generated files with the files in the output
+	// folder and fails if they differ.
+	check bool
+}
+
+func NewCommandLLMs(connectorsFile, specsFolder, pageIndexFile, outputFolder string, check bool) *CommandLLMs {
+	return &CommandLLMs{
+		connectorsFile: connectorsFile,
+		specsFolder:    specsFolder,
+		pageIndexFile:  pageIndexFile,
+		outputFolder:   outputFolder,
+		check:          check,
+	}
+}
+
+func (cmd *CommandLLMs) Execute(context.Context) error {
+	cfg, err := parseCatalogConfig(catalogConfigYaml)
+	if err != nil {
+		return err
+	}
+	trustCfg, err := parseTrustConfig(trustConfigYaml)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("👀 Reading %s ...\n", cmd.connectorsFile)
+
+	data, err := os.ReadFile(cmd.connectorsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	var repositories []Repository
+	if err := json.Unmarshal(data, &repositories); err != nil {
+		return fmt.Errorf("failed to parse JSON input: %w", err)
+	}
+
+	index, err := readPageIndex(cmd.pageIndexFile)
+	if err != nil {
+		return err
+	}
+
+	// loading specifications works the same as for pages
+	docs := NewCommandDocs(cmd.connectorsFile, cmd.specsFolder, cmd.pageIndexFile, nil)
+
+	connectors := make([]llmsConnector, 0, len(repositories))
+	for _, repo := range repositories {
+		repo.Trust = trustCfg.classify(repo)
+		specifications := docs.loadSpecifications(repo)
+		latest, ok := specifications["latest"]
+
+		entry := cfg.entry(repo, specifications)
+		if slug, ok := index.Pages[repo.NameWithOwner]; ok {
+			entry.Page = connectorPagesURL + slug
+		}
+		connectors = append(connectors, llmsConnector{
+			catalogEntry:  entry,
+			Specified:     ok,
+			Specification: latest,
+		})
+	}
+
+	files, err := renderLLMs(cfg, connectors)
+	if err != nil {
+		return err
+	}
+
+	if cmd.check {
+		return checkLLMs(cmd.outputFolder, files)
+	}
+
+	if err := os.MkdirAll(cmd.outputFolder, 0755); err != nil {
+		return fmt.Errorf("failed to create output folder: %w", err)
+	}
+	for _, name := range []string{llmsFile, llmsFullFile} {
+		path := filepath.Join(cmd.outputFolder, name)
+		fmt.Printf("💾 Writing %s ...\n", path)
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	fmt.Printf("✅ Described %d connectors\n", len(connectors))
+	return nil
+}
+
+// renderLLMs renders the llms.txt files and returns their contents by file
+// name. Connectors are sorted by name, so the output doesn't depend on the
+// order of the registry.
+func renderLLMs(cfg catalogConfig, connectors []llmsConnector) (map[string][]byte, error) {
+	connectors = slices.Clone(connectors)
+	slices.SortStableFunc(connectors, func(a, b llmsConnector) int {
+		if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return strings.Compare(strings.ToLower(a.NameWithOwner), strings.ToLower(b.NameWithOwner))
+	})
+
+	entries := make([]catalogEntry, len(connectors))
+	for i, c := range connectors {
+		entries[i] = c.catalogEntry
+	}
+	d := llmsData{
+		Categories: cfg.catalog(entries).sections(),
+		Connectors: connectors,
+	}
+
+	tmpl, err := template.New("llms").Funcs(template.FuncMap{
+		"siteURL":       func() string { return siteURL },
+		"oneLine":       oneLine,
+		"plugins":       pluginsLabel,
+		"llmsParameter": llmsParameter,
+		"join":          strings.Join,
+	}).Parse(llmsTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse llms template: %w", err)
+	}
+
+	files := make(map[string][]byte, 2)
+	for name, tmplName := range map[string]string{llmsFile: "llms", llmsFullFile: "llms-full"} {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, tmplName, d); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", name, err)
+		}
+		files[name] = rewriteDomain(buf.Bytes())
+	}
+	return files, nil
+}
+
+// checkLLMs compares the generated files with the files in the folder and
+// returns an error listing the files that are stale.
+func checkLLMs(folder string, files map[string][]byte) error {
+	var stale []string
+	for _, name := range []string{llmsFile, llmsFullFile} {
+		path := filepath.Join(folder, name)
+		fmt.Printf("🔍 Checking %s ...\n", path)
+		existing, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if !bytes.Equal(existing, files[name]) {
+			stale = append(stale, path)
+		}
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("llms files are stale, regenerate them with `make llms`: %s", strings.Join(stale, ", "))
+	}
+	fmt.Println("✅ llms files are up to date")
+	return nil
+}
+
+// oneLine collapses whitespace, so multiline text fits into a list item.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// pluginsLabel describes which plugins a connector implements.
+func pluginsLabel(source, destination bool) string {
+	switch {
+	case source && destination:
+		return "source and destination"
+	case source:
+		return "source"
+	case destination:
+		return "destination"
+	default:
+		return "plugins not specified"
+	}
+}
+
+// llmsParameter describes a parameter in a single line, including its exact
+// key, type, default value and validations.
+func llmsParameter(p Parameter) string {
+	details := []string{p.Type}
+	if p.Default != "" {
+		details = append(details, fmt.Sprintf("default: `%s`", oneLine(p.Default)))
+	}
+	for _, v := range p.Validations {
+		details = append(details, v.Describe())
+	}
+
+	line := fmt.Sprintf("`%s` (%s)", p.Name, strings.Join(details, "; "))
+	if description := oneLine(p.Description); description != "" {
+		line += ": " + description
+	}
+	return line
+}
@@ -0,0 +1,154 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLLMsParameter(t *testing.T) {
+	tests := []struct {
+		name  string
+		param Parameter
+		want  string
+	}{{
+		name:  "type only",
+		param: Parameter{Name: "tls.enabled", Type: "bool"},
+		want:  "`tls.enabled` (bool)",
+	}, {
+		name: "default, validations and multiline description",
+		param: Parameter{
+			Name:        "mode",
+			Type:        "string",
+			Default:     "snapshot",
+			Description: "Mode of the source.\n\nEither snapshot or cdc.",
+			Validations: []Validation{{Type: "required"}, {Type: "inclusion", Value: "snapshot,cdc"}},
+		},
+		want: "`mode` (string; default: `snapshot`; required; one of: snapshot, cdc): Mode of the source. Either snapshot or cdc.",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := llmsParameter(tt.param); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderLLMs(t *testing.T) {
+	cfg, err := parseCatalogConfig([]byte("categories: [Databases, Messaging]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connectors := []llmsConnector{{
+		catalogEntry: catalogEntry{
+			Name:          "postgres",
+			NameWithOwner: "ConduitIO/conduit-connector-postgres",
+			URL:           "https://github.com/ConduitIO/conduit-connector-postgres",
+			Page:          connectorPagesURL + "postgres",
+			Summary:       "Conduit connector for\nPostgreSQL, see conduit.io",
+			Source:        true,
+			Categories:    []string{"Databases"},
+			LatestVersion: "v0.14.0",
+			Trust:         TrustOfficial,
+		},
+		Specified: true,
+		Specification: Specification{
+			Name: "postgres",
+			Source: &PluginSpecification{Parameters: []Parameter{{
+				Name: "url", Type: "string", Validations: []Validation{{Type: "required"}},
+			}}},
+		},
+	}, {
+		catalogEntry: catalogEntry{
+			Name:          "kafka",
+			NameWithOwner: "acme/conduit-connector-kafka",
+			URL:           "https://github.com/acme/conduit-connector-kafka",
+			Categories:    []string{"Messaging"},
+			Trust:         TrustCommunity,
+		},
+	}}
+
+	files, err := renderLLMs(cfg, connectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index := string(files[llmsFile])
+	for _, want := range []string{
+		"# Conduit connectors",
+		"## Databases\n\n- [postgres](https://conduitdata.io/docs/using/connectors/list/postgres): Conduit connector for PostgreSQL, see conduitdata.io (ConduitIO/conduit-connector-postgres, source, Official)",
+		"## Messaging\n\n- [kafka](https://github.com/acme/conduit-connector-kafka) (acme/conduit-connector-kafka, plugins not specified, Community)",
+	} {
+		if !strings.Contains(index, want) {
+			t.Errorf("expected %s to contain %q, got:\n%s", llmsFile, want, index)
+		}
+	}
+
+	full := string(files[llmsFullFile])
+	for _, want := range []string{
+		"- Install: `conduit connectors install postgres@v0.14.0`",
+		"### Source parameters\n\n- `url` (string; required)",
+		"No specification is available for this connector",
+	} {
+		if !strings.Contains(full, want) {
+			t.Errorf("expected %s to contain %q, got:\n%s", llmsFullFile, want, full)
+		}
+	}
+	// connectors are sorted by name
+	if strings.Index(full, "## kafka") > strings.Index(full, "## postgres") {
+		t.Errorf("expected kafka to be listed before postgres, got:\n%s", full)
+	}
+
+	// rendering is deterministic, regardless of the input order
+	again, err := renderLLMs(cfg, []llmsConnector{connectors[1], connectors[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if string(again[name]) != string(content) {
+			t.Errorf("expected %s to be rendered identically", name)
+		}
+	}
+}
+
+func TestCheckLLMs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{llmsFile: []byte("index\n"), llmsFullFile: []byte("full\n")}
+
+	err := checkLLMs(dir, files)
+	if err == nil || !strings.Contains(err.Error(), "stale") {
+		t.Fatalf("expected stale error for missing files, got %v", err)
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := checkLLMs(dir, files); err != nil {
+		t.Fatalf("expected files to be up to date, got %v", err)
+	}
+
+	files[llmsFullFile] = []byte("changed\n")
+	err = checkLLMs(dir, files)
+	if err == nil || !strings.Contains(err.Error(), llmsFullFile) || strings.Contains(err.Error(), filepath.Join(dir, llmsFile)+",") {
+		t.Fatalf("expected only %s to be stale, got %v", llmsFullFile, err)
+	}
+}
@@ -26,9 +26,17 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == sandboxInitArg {
+		sandboxInit(os.Args[2:])
+		return
+	}
+
 	cmdRoot := &cobra.Command{
 		Use:   "connectorgen",
 		Short: "Tooling around generating connector related files",
+		// Errors are printed in main, usage is only useful for invalid flags
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
 
 	cmdRegistry := &cobra.Command{
@@ -62,13 +70,37 @@ func main() {
 
 			connectorsPath := cmd.Flag("connectors").Value.String()
 			outputPath := cmd.Flag("output").Value.String()
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
+			binaryFallback, err := cmd.Flags().GetBool("binary-fallback")
+			if err != nil {
+				return err
+			}
 
-			return NewCommandSpecifications(githubClient, connectorsPath, outputPath, false).Execute(cmd.Context())
+			return NewCommandSpecifications(githubClient, connectorsPath, outputPath, force, binaryFallback).Execute(cmd.Context())
 		},
 	}
 	cmdSpecifications.Flags().StringP("connectors", "c", "./connectors.json", "path to the connectors.json file")
 	cmdSpecifications.Flags().StringP("output", "o", "./connectors", "path to the folder where the output files will be written")
 	cmdSpecifications.Flags().BoolP("force", "f", false, "force fetching of connector.yaml even if it already exists")
+	cmdSpecifications.Flags().Bool("binary-fallback", false, "derive connector.yaml from the linux/amd64 release binary if the repository doesn't contain one, the binary runs without isolation, only use it for trusted connectors")
+
+	cmdValidateSpecifications := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate downloaded connector.yaml specifications",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectorsPath := cmd.Flag("connectors").Value.String()
+			specsPath := cmd.Flag("specs").Value.String()
+
+			return NewCommandValidateSpecifications(connectorsPath, specsPath).Execute(cmd.Context())
+		},
+	}
+	cmdValidateSpecifications.Flags().StringP("connectors", "c", "./connectors.json", "path to the connectors.json file")
+	cmdValidateSpecifications.Flags().StringP("specs", "s", "./connectors", "path to the connector specifications folder")
+	cmdSpecifications.AddCommand(cmdValidateSpecifications)
 
 	cmdPages := &cobra.Command{
 		Use:   "pages",
@@ -78,18 +110,138 @@ func main() {
 			connectorsPath := cmd.Flag("connectors").Value.String()
 			specsPath := cmd.Flag("specs").Value.String()
 			outputPath := cmd.Flag("output").Value.String()
+			pageIndexPath := cmd.Flag("page-index").Value.String()
+			targetFlags, err := cmd.Flags().GetStringArray("target")
+			if err != nil {
+				return err
+			}
+			templateFlags, err := cmd.Flags().GetStringArray("template")
+			if err != nil {
+				return err
+			}
+			targets, err := parsePageTargets(targetFlags, templateFlags, outputPath)
+			if err != nil {
+				return err
+			}
 
-			return NewCommandDocs(connectorsPath, specsPath, outputPath).Execute(cmd.Context())
+			return NewCommandDocs(connectorsPath, specsPath, pageIndexPath, targets).Execute(cmd.Context())
 		},
 	}
 	cmdPages.Flags().StringP("connectors", "c", "./connectors.json", "path to the connectors.json file")
 	cmdPages.Flags().StringP("specs", "s", "./connectors", "path to the connector specifications folder")
 	cmdPages.Flags().StringP("output", "o", "./docs", "path to the folder where the output files will be written")
+	cmdPages.Flags().StringP("page-index", "p", "./connector-pages.json", "path to the file recording page slugs and redirects")
+	cmdPages.Flags().StringArrayP("target", "t", []string{"mdx"}, "output target as <name>[=<folder>] (mdx, markdown or html), pages are written to --output if no folder is set; can be repeated")
+	cmdPages.Flags().StringArray("template", nil, "path to a template overriding the embedded template, as [<target>=]<path>; can be repeated")
+
+	cmdLint := &cobra.Command{
+		Use:   "lint",
+		Short: "Check generated connector pages for MDX syntax errors",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectorsPath := cmd.Flag("connectors").Value.String()
+			specsPath := cmd.Flag("specs").Value.String()
+			pagesPath := cmd.Flag("pages").Value.String()
+			pageIndexPath := cmd.Flag("page-index").Value.String()
+
+			return NewCommandLint(connectorsPath, specsPath, pagesPath, pageIndexPath).Execute(cmd.Context())
+		},
+	}
+	cmdLint.Flags().StringP("connectors", "c", "./connectors.json", "path to the connectors.json file")
+	cmdLint.Flags().StringP("specs", "s", "./connectors", "path to the connector specifications folder")
+	cmdLint.Flags().StringP("pages", "o", "./docs", "path to the folder containing the generated pages")
+	cmdLint.Flags().StringP("page-index", "p", "./connector-pages.json", "path to the file recording page slugs and redirects")
+
+	cmdCatalog := &cobra.Command{
+		Use:   "catalog",
+		Short: "Generate the connector catalog JSON and the page listing connectors by category",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectorsPath := cmd.Flag("connectors").Value.String()
+			specsPath := cmd.Flag("specs").Value.String()
+			pageIndexPath := cmd.Flag("page-index").Value.String()
+			outputPath := cmd.Flag("output").Value.String()
+			pagePath := cmd.Flag("page").Value.String()
+
+			return NewCommandCatalog(connectorsPath, specsPath, pageIndexPath, outputPath, pagePath).Execute(cmd.Context())
+		},
+	}
+	cmdCatalog.Flags().StringP("connectors", "c", "./connectors.json", "path to the connectors.json file")
+	cmdCatalog.Flags().StringP("specs", "s", "./connectors", "path to the connector specifications folder")
+	cmdCatalog.Flags().StringP("page-index", "p", "./connector-pages.json", "path to the file recording page slugs and redirects")
+	cmdCatalog.Flags().StringP("output", "o", "./catalog.json", "path where the catalog will be written")
+	cmdCatalog.Flags().String("page", "./categories.mdx", "path where the page listing connectors by category will be written")
+
+	cmdLLMs := &cobra.Command{
+		Use:   "llms",
+		Short: "Generate the connector sections of llms.txt and llms-full.txt for AI agents",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectorsPath := cmd.Flag("connectors").Value.String()
+			specsPath := cmd.Flag("specs").Value.String()
+			pageIndexPath := cmd.Flag("page-index").Value.String()
+			outputPath := cmd.Flag("output").Value.String()
+			check, err := cmd.Flags().GetBool("check")
+			if err != nil {
+				return err
+			}
+
+			return NewCommandLLMs(connectorsPath, specsPath, pageIndexPath, outputPath, check).Execute(cmd.Context())
+		},
+	}
+	cmdLLMs.Flags().StringP("connectors", "c", "./connectors.json", "path to the connectors.json file")
+	cmdLLMs.Flags().StringP("specs", "s", "./connectors", "path to the connector specifications folder")
+	cmdLLMs.Flags().StringP("page-index", "p", "./connector-pages.json", "path to the file recording page slugs and redirects")
+	cmdLLMs.Flags().StringP("output", "o", "./llms", "path to the folder where connectors.txt and connectors-full.txt will be written")
+	cmdLLMs.Flags().Bool("check", false, "fail if the files in the output folder are stale instead of writing them")
+
+	cmdSchema := &cobra.Command{
+		Use:   "schema",
+		Short: "Generate JSON Schemas for the source and destination settings of connectors",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectorsPath := cmd.Flag("connectors").Value.String()
+			specsPath := cmd.Flag("specs").Value.String()
+			pageIndexPath := cmd.Flag("page-index").Value.String()
+			outputPath := cmd.Flag("output").Value.String()
+
+			return NewCommandSchema(connectorsPath, specsPath, pageIndexPath, outputPath).Execute(cmd.Context())
+		},
+	}
+	cmdSchema.Flags().StringP("connectors", "c", "./connectors.json", "path to the connectors.json file")
+	cmdSchema.Flags().StringP("specs", "s", "./connectors", "path to the connector specifications folder")
+	cmdSchema.Flags().StringP("page-index", "p", "./connector-pages.json", "path to the file recording page slugs and redirects")
+	cmdSchema.Flags().StringP("output", "o", "./schemas", "path to the folder where the schemas will be written")
+
+	cmdValidatePipeline := &cobra.Command{
+		Use:   "validate-pipeline <file.yaml>",
+		Short: "Validate a pipeline configuration file against connector and processor specifications",
+		Long: `Validate a pipeline configuration file (version 2.2) offline. Connector and
+processor plugins are resolved to their specifications (honouring @version
+suffixes) and settings are checked for unknown keys, invalid types, violated
+validations and missing required parameters.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectorsPath := cmd.Flag("connectors").Value.String()
+			specsPath := cmd.Flag("specs").Value.String()
+			processorsPath := cmd.Flag("processors").Value.String()
+
+			return NewCommandValidatePipeline(connectorsPath, specsPath, processorsPath, args[0]).Execute(cmd.Context())
+		},
+	}
+	cmdValidatePipeline.Flags().StringP("connectors", "c", "./connectors.json", "path to the connectors.json file")
+	cmdValidatePipeline.Flags().StringP("specs", "s", "./connectors", "path to the connector specifications folder")
+	cmdValidatePipeline.Flags().StringP("processors", "P", "../processorgen/specs", "path to the builtin processor specifications folder")
 
 	cmdRoot.AddCommand(
 		cmdRegistry,
 		cmdSpecifications,
 		cmdPages,
+		cmdCatalog,
+		cmdLLMs,
+		cmdSchema,
+		cmdValidatePipeline,
+		cmdLint,
 	)
 	cmdRoot.CompletionOptions.DisableDefaultCmd = true
 
@@ -0,0 +1,198 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/genfuncs"
+)
+
+// connectorPagesURL is the URL under which the connector pages are published.
+const connectorPagesURL = "/docs/using/connectors/list/"
+
+// reservedSlugs are the slugs of pages in the connector list that are not
+// connector pages.
+var reservedSlugs = []string{"index", "categories"}
+
+// pageIndex records the page slug and sidebar position of each connector and
+// the redirects for pages that were renamed. It is persisted between runs of
+// the pages command, so slugs and positions stay stable and renamed pages can
+// be detected. The redirects are loaded by docusaurus.config.ts.
+type pageIndex struct {
+	// Pages maps the repository name with owner to the slug of its page.
+	Pages map[string]string `json:"pages"`
+	// Positions maps the repository name with owner to the position of its
+	// page in the sidebar.
+	Positions map[string]int `json:"positions"`
+	Redirects []pageRedirect `json:"redirects"`
+}
+
+type pageRedirect struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// connectorPage is a connector page that is going to be generated.
+type connectorPage struct {
+	Repository     Repository
+	Name           string
+	Specifications map[string]Specification
+	// Position is the position of the page in the sidebar.
+	Position int
+	Slug     string
+}
+
+func readPageIndex(path string) (pageIndex, error) {
+	index := pageIndex{Pages: map[string]string{}, Positions: map[string]int{}}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	} else if err != nil {
+		return pageIndex{}, fmt.Errorf("failed to read page index: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return pageIndex{}, fmt.Errorf("failed to parse page index %s: %w", path, err)
+	}
+	if index.Pages == nil {
+		index.Pages = map[string]string{}
+	}
+	if index.Positions == nil {
+		index.Positions = map[string]int{}
+	}
+	return index, nil
+}
+
+func (pi pageIndex) write(path string) error {
+	raw, err := json.MarshalIndent(pi, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal page index: %w", err)
+	}
+	return os.WriteFile(path, append(raw, '\n'), 0644)
+}
+
+// assignSlugs sets the slug and position of each page and returns the updated
+// page index. A page is named after its connector. If multiple repositories
+// declare the same connector name, the repository that already owns the slug
+// (or else the first one) keeps it, the others get a slug suffixed with the
+// repository owner. Pages whose slug changed get a redirect from the old URL.
+// Pages keep their position, new pages are appended in the order of their
+// slugs, so adding a connector doesn't move the other pages.
+func (pi pageIndex) assignSlugs(pages []connectorPage) pageIndex {
+	owners := make(map[string]string, len(pages)) // slug -> repository
+	for _, slug := range reservedSlugs {
+		owners[slug] = "a generated page"
+	}
+	claim := func(slug, repo string) bool {
+		if other, ok := owners[slug]; ok && other != repo {
+			return false
+		}
+		owners[slug] = repo
+		return true
+	}
+
+	// Repositories keep the slug they own if the connector name still
+	// results in that slug, this makes the result independent of the order.
+	for _, p := range pages {
+		if previous, ok := pi.Pages[p.Repository.NameWithOwner]; ok && previous == genfuncs.Slugify(p.Name) {
+			claim(previous, p.Repository.NameWithOwner)
+		}
+	}
+
+	next := pageIndex{
+		Pages:     make(map[string]string, len(pages)),
+		Positions: make(map[string]int, len(pages)),
+		Redirects: []pageRedirect{},
+	}
+	for i := range pages {
+		p := &pages[i]
+		repo := p.Repository.NameWithOwner
+		slug := genfuncs.Slugify(p.Name)
+		if !claim(slug, repo) {
+			owner, _, _ := strings.Cut(repo, "/")
+			fmt.Printf("  ⚠️  Warning: connector name %q of %s is already used by %s\n", p.Name, repo, owners[slug])
+			base := genfuncs.Slugify(p.Name + "-" + owner)
+			slug = base
+			for n := 2; !claim(slug, repo); n++ {
+				slug = base + "-" + strconv.Itoa(n)
+			}
+		}
+		p.Slug = slug
+		next.Pages[repo] = slug
+	}
+
+	last := 0
+	var added []*connectorPage
+	for i := range pages {
+		p := &pages[i]
+		if pos, ok := pi.Positions[p.Repository.NameWithOwner]; ok {
+			p.Position = pos
+			last = max(last, pos)
+			continue
+		}
+		added = append(added, p)
+	}
+	slices.SortFunc(added, func(a, b *connectorPage) int {
+		return strings.Compare(a.Slug, b.Slug)
+	})
+	for _, p := range added {
+		last++
+		p.Position = last
+	}
+	for _, p := range pages {
+		next.Positions[p.Repository.NameWithOwner] = p.Position
+	}
+
+	// Keep previous redirects pointing at the current page URLs and add
+	// redirects for renamed pages.
+	current := make(map[string]string, len(pi.Pages)) // old URL -> new URL
+	for repo, oldSlug := range pi.Pages {
+		if newSlug, ok := next.Pages[repo]; ok && newSlug != oldSlug {
+			current[connectorPagesURL+oldSlug] = connectorPagesURL + newSlug
+		}
+	}
+	redirects := slices.Clone(pi.Redirects)
+	for from, to := range current {
+		redirects = append(redirects, pageRedirect{From: from, To: to})
+	}
+
+	existing := make(map[string]bool, len(next.Pages))
+	for _, slug := range next.Pages {
+		existing[connectorPagesURL+slug] = true
+	}
+	seen := map[string]bool{}
+	for _, r := range redirects {
+		if to, ok := current[r.To]; ok {
+			r.To = to
+		}
+		// A redirect from an existing page would shadow it.
+		if existing[r.From] || seen[r.From] || r.From == r.To {
+			continue
+		}
+		seen[r.From] = true
+		next.Redirects = append(next.Redirects, r)
+	}
+	slices.SortFunc(next.Redirects, func(a, b pageRedirect) int {
+		return strings.Compare(a.From, b.From)
+	})
+	return next
+}
@@ -0,0 +1,89 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPageIndexAssignSlugs(t *testing.T) {
+	page := func(repo, name string) connectorPage {
+		return connectorPage{Repository: Repository{NameWithOwner: repo}, Name: name}
+	}
+
+	previous := pageIndex{
+		Pages: map[string]string{
+			"acme/conduit-connector-pg":      "pg",
+			"other/conduit-connector-s3":     "s3",
+			"acme/conduit-connector-gone":    "gone",
+			"conduitio/conduit-connector-s3": "s3-conduitio",
+		},
+		Positions: map[string]int{
+			"acme/conduit-connector-pg":   2,
+			"other/conduit-connector-s3":  1,
+			"acme/conduit-connector-gone": 5,
+		},
+		Redirects: []pageRedirect{
+			{From: connectorPagesURL + "postgresql", To: connectorPagesURL + "pg"},
+			{From: connectorPagesURL + "kafka", To: connectorPagesURL + "old-kafka"},
+		},
+	}
+	pages := []connectorPage{
+		// renamed from pg
+		page("acme/conduit-connector-pg", "postgres"),
+		// collides with other/conduit-connector-s3, which already owns the slug
+		page("conduitio/conduit-connector-s3", "s3"),
+		page("other/conduit-connector-s3", "s3"),
+		// new page taking over the URL of a redirect
+		page("acme/conduit-connector-kafka", "kafka"),
+	}
+
+	got := previous.assignSlugs(pages)
+
+	want := pageIndex{
+		Pages: map[string]string{
+			"acme/conduit-connector-pg":      "postgres",
+			"conduitio/conduit-connector-s3": "s3-conduitio",
+			"other/conduit-connector-s3":     "s3",
+			"acme/conduit-connector-kafka":   "kafka",
+		},
+		// new pages are appended in the order of their slugs
+		Positions: map[string]int{
+			"acme/conduit-connector-pg":      2,
+			"conduitio/conduit-connector-s3": 4,
+			"other/conduit-connector-s3":     1,
+			"acme/conduit-connector-kafka":   3,
+		},
+		Redirects: []pageRedirect{
+			{From: connectorPagesURL + "pg", To: connectorPagesURL + "postgres"},
+			{From: connectorPagesURL + "postgresql", To: connectorPagesURL + "postgres"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected page index\nwant: %+v\ngot:  %+v", want, got)
+	}
+
+	for i, slug := range []string{"postgres", "s3-conduitio", "s3", "kafka"} {
+		if pages[i].Slug != slug {
+			t.Errorf("page %d: expected slug %q, got %q", i, slug, pages[i].Slug)
+		}
+	}
+	for i, pos := range []int{2, 4, 1, 3} {
+		if pages[i].Position != pos {
+			t.Errorf("page %d: expected position %d, got %d", i, pos, pages[i].Position)
+		}
+	}
+}
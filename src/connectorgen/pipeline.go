@@ -0,0 +1,174 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pipelineConfig is a Conduit pipeline configuration file (version 2.x). It
+// is used to parse configuration files and to generate example
+// configurations.
+type pipelineConfig struct {
+	Version   pipelineVersion          `yaml:"version"`
+	Pipelines []pipelineConfigPipeline `yaml:"pipelines"`
+}
+
+// pipelineVersion is the version of a pipeline configuration file, it is
+// written as a number (e.g. 2.2) like in the documentation.
+type pipelineVersion string
+
+// supportedPipelineVersions are the versions of the configuration file format
+// (v2) that Conduit can parse, the fields checked by validate-pipeline are the
+// same in all of them.
+var supportedPipelineVersions = []string{"2.0", "2.1", "2.2"}
+
+func (v pipelineVersion) MarshalYAML() (any, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: string(v)}, nil
+}
+
+type pipelineConfigPipeline struct {
+	ID          string                    `yaml:"id"`
+	Status      string                    `yaml:"status,omitempty"`
+	Name        string                    `yaml:"name,omitempty"`
+	Description string                    `yaml:"description,omitempty"`
+	Connectors  []pipelineConfigConnector `yaml:"connectors,omitempty"`
+	Processors  []pipelineConfigProcessor `yaml:"processors,omitempty"`
+	DLQ         *pipelineConfigDLQ        `yaml:"dead-letter-queue,omitempty"`
+
+	// Line is the line of the pipeline in the configuration file.
+	Line int `yaml:"-"`
+}
+
+type pipelineConfigConnector struct {
+	ID         string                    `yaml:"id"`
+	Type       string                    `yaml:"type"`
+	Plugin     string                    `yaml:"plugin"`
+	Name       string                    `yaml:"name,omitempty"`
+	Settings   pipelineSettings          `yaml:"settings,omitempty"`
+	Processors []pipelineConfigProcessor `yaml:"processors,omitempty"`
+
+	Line int `yaml:"-"`
+}
+
+type pipelineConfigProcessor struct {
+	ID        string           `yaml:"id"`
+	Plugin    string           `yaml:"plugin"`
+	Condition string           `yaml:"condition,omitempty"`
+	Workers   int              `yaml:"workers,omitempty"`
+	Settings  pipelineSettings `yaml:"settings,omitempty"`
+
+	Line int `yaml:"-"`
+}
+
+type pipelineConfigDLQ struct {
+	Plugin              string           `yaml:"plugin"`
+	Settings            pipelineSettings `yaml:"settings,omitempty"`
+	WindowSize          *int             `yaml:"window-size,omitempty"`
+	WindowNackThreshold *int             `yaml:"window-nack-threshold,omitempty"`
+
+	Line int `yaml:"-"`
+}
+
+// pipelineSetting is a single setting of a connector or processor, Line is
+// the line of the key in the configuration file.
+type pipelineSetting struct {
+	Key   string
+	Value string
+	Line  int
+	// Comment is written above the setting when generating a configuration.
+	Comment string
+}
+
+// pipelineSettings are the settings of a connector or processor in the order
+// in which they are defined.
+type pipelineSettings []pipelineSetting
+
+func (s *pipelineSettings) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: settings must be a mapping", n.Line)
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return fmt.Errorf("line %d: setting %q must be a scalar value", value.Line, key.Value)
+		}
+		*s = append(*s, pipelineSetting{Key: key.Value, Value: value.Value, Line: key.Line})
+	}
+	return nil
+}
+
+func (s pipelineSettings) MarshalYAML() (any, error) {
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	for _, setting := range s {
+		value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: setting.Value, Style: yaml.DoubleQuotedStyle}
+		if strings.Contains(setting.Value, "\n") {
+			value.Style = yaml.LiteralStyle
+		}
+		n.Content = append(n.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: setting.Key, HeadComment: setting.Comment},
+			value,
+		)
+	}
+	return n, nil
+}
+
+func (p *pipelineConfigPipeline) UnmarshalYAML(n *yaml.Node) error {
+	type raw pipelineConfigPipeline
+	if err := n.Decode((*raw)(p)); err != nil {
+		return err
+	}
+	p.Line = n.Line
+	return nil
+}
+
+func (c *pipelineConfigConnector) UnmarshalYAML(n *yaml.Node) error {
+	type raw pipelineConfigConnector
+	if err := n.Decode((*raw)(c)); err != nil {
+		return err
+	}
+	c.Line = n.Line
+	return nil
+}
+
+func (p *pipelineConfigProcessor) UnmarshalYAML(n *yaml.Node) error {
+	type raw pipelineConfigProcessor
+	if err := n.Decode((*raw)(p)); err != nil {
+		return err
+	}
+	p.Line = n.Line
+	return nil
+}
+
+func (d *pipelineConfigDLQ) UnmarshalYAML(n *yaml.Node) error {
+	type raw pipelineConfigDLQ
+	if err := n.Decode((*raw)(d)); err != nil {
+		return err
+	}
+	d.Line = n.Line
+	return nil
+}
+
+// parsePipelineConfig parses a pipeline configuration file.
+func parsePipelineConfig(raw []byte) (pipelineConfig, error) {
+	var cfg pipelineConfig
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return pipelineConfig{}, fmt.Errorf("failed to parse pipeline configuration: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,105 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/conduitio/genfuncs"
+)
+
+var (
+	// readmeHTMLComment matches HTML comments, including readmegen markers
+	// (e.g. <!-- readmegen:description -->).
+	readmeHTMLComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// readmeLink matches inline links and images, the second group is the
+	// link destination.
+	readmeLink = regexp.MustCompile(`(!?\[[^\]]*\]\()([^)\s]+)`)
+	// readmeLinkDefinition matches link reference definitions.
+	readmeLinkDefinition = regexp.MustCompile(`(?m)^( {0,3}\[[^\]]+\]:\s*)(\S+)`)
+	// readmeAutolink matches autolinks like <https://conduit.io>.
+	readmeAutolink = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	// readmeHeading matches ATX headings.
+	readmeHeading = regexp.MustCompile(`(?m)^(#{1,6})(\s)`)
+)
+
+// readmeHeadingOffset is the number of levels README headings are shifted
+// down, so they are nested below the "Documentation" heading of the page.
+const readmeHeadingOffset = 2
+
+// sanitizeReadme converts a GitHub README into content that can be embedded in
+// an MDX page. HTML comments (including readmegen markers) are removed, MDX
+// syntax characters are escaped, relative links and images are rewritten to
+// absolute GitHub URLs at ref and headings are nested below the page heading.
+// Fenced code blocks are left untouched.
+func sanitizeReadme(readme, nameWithOwner, ref string) string {
+	var sb strings.Builder
+	for _, segment := range genfuncs.SplitCodeBlocks(strings.ReplaceAll(readme, "\r\n", "\n")) {
+		if segment.Code {
+			sb.WriteString(segment.Text)
+			continue
+		}
+
+		text := readmeHTMLComment.ReplaceAllString(segment.Text, "")
+		text = readmeAutolink.ReplaceAllString(text, "$1")
+		text = readmeLink.ReplaceAllStringFunc(text, func(match string) string {
+			m := readmeLink.FindStringSubmatch(match)
+			image := strings.HasPrefix(m[1], "!")
+			return m[1] + absoluteReadmeURL(m[2], nameWithOwner, ref, image)
+		})
+		text = readmeLinkDefinition.ReplaceAllStringFunc(text, func(match string) string {
+			m := readmeLinkDefinition.FindStringSubmatch(match)
+			return m[1] + absoluteReadmeURL(m[2], nameWithOwner, ref, false)
+		})
+		text = readmeHeading.ReplaceAllStringFunc(text, func(match string) string {
+			level := min(strings.Count(match, "#")+readmeHeadingOffset, 6)
+			return strings.Repeat("#", level) + match[len(match)-1:]
+		})
+		sb.WriteString(genfuncs.EscapeMDXText(text))
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// absoluteReadmeURL returns the absolute URL of a link found in the README.
+// Relative images point to raw.githubusercontent.com, other relative links to
+// the file on github.com. Absolute URLs and anchors are returned unchanged.
+func absoluteReadmeURL(link, nameWithOwner, ref string, image bool) string {
+	u, err := url.Parse(link)
+	if err != nil || u.IsAbs() || u.Host != "" || link == "" || strings.HasPrefix(link, "#") {
+		return link
+	}
+
+	p := path.Clean("/" + u.Path)
+	if image {
+		return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s%s%s", nameWithOwner, ref, p, urlSuffix(u))
+	}
+	return fmt.Sprintf("https://github.com/%s/blob/%s%s%s", nameWithOwner, ref, p, urlSuffix(u))
+}
+
+// urlSuffix returns the query and fragment of the URL.
+func urlSuffix(u *url.URL) string {
+	var s string
+	if u.RawQuery != "" {
+		s += "?" + u.RawQuery
+	}
+	if u.Fragment != "" {
+		s += "#" + u.EscapedFragment()
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+)
+
+func TestSanitizeReadme(t *testing.T) {
+	readme := "# Conduit Connector Foo\r\n" +
+		"\r\n" +
+		"<!-- readmegen:description -->\r\n" +
+		"Reads records from {Foo} <servers>, see <https://foo.io>.\r\n" +
+		"<!-- /readmegen:description -->\r\n" +
+		"\r\n" +
+		"![logo](docs/logo.png) [license](./LICENSE) [docs](https://foo.io/docs) [top](#top)\r\n" +
+		"\r\n" +
+		"## Configuration\r\n" +
+		"\r\n" +
+		"Use `{{ .Key }}` or ``a ` <b>``.\r\n" +
+		"\r\n" +
+		"```yaml\r\n" +
+		"# not a heading {x}\r\n" +
+		"<!-- keep -->\r\n" +
+		"```\r\n" +
+		"\r\n" +
+		"[ref]: ../other.md#section\r\n"
+
+	want := "### Conduit Connector Foo\n" +
+		"\n" +
+		"\n" +
+		"Reads records from \\{Foo\\} \\<servers>, see https://foo.io.\n" +
+		"\n" +
+		"\n" +
+		"![logo](https://raw.githubusercontent.com/acme/conduit-connector-foo/v1.0.0/docs/logo.png) " +
+		"[license](https://github.com/acme/conduit-connector-foo/blob/v1.0.0/LICENSE) " +
+		"[docs](https://foo.io/docs) [top](#top)\n" +
+		"\n" +
+		"#### Configuration\n" +
+		"\n" +
+		"Use `{{ .Key }}` or ``a ` <b>``.\n" +
+		"\n" +
+		"```yaml\n" +
+		"# not a heading {x}\n" +
+		"<!-- keep -->\n" +
+		"```\n" +
+		"\n" +
+		"[ref]: https://github.com/acme/conduit-connector-foo/blob/v1.0.0/other.md#section"
+
+	got := sanitizeReadme(readme, "acme/conduit-connector-foo", "v1.0.0")
+	if got != want {
+		t.Fatalf("unexpected output\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -15,10 +15,13 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"regexp"
 	"slices"
@@ -94,13 +97,15 @@ var knownArch = map[string]bool{
 
 // Repository represents GitHub repository information.
 type Repository struct {
-	NameWithOwner string    `json:"name_with_owner"`
-	Description   string    `json:"description"`
-	CreatedAt     string    `json:"created_at"`
-	URL           string    `json:"url"`
-	Stargazers    int       `json:"stargazer_count"`
-	Forks         int       `json:"fork_count"`
-	Releases      []Release `json:"releases"`
+	NameWithOwner string     `json:"name_with_owner"`
+	Description   string     `json:"description"`
+	CreatedAt     string     `json:"created_at"`
+	URL           string     `json:"url"`
+	Stargazers    int        `json:"stargazer_count"`
+	Forks         int        `json:"fork_count"`
+	Topics        []string   `json:"topics,omitempty"`
+	Trust         TrustLevel `json:"trust,omitempty"`
+	Releases      []Release  `json:"releases"`
 }
 
 // Release represents a GitHub release.
@@ -127,6 +132,9 @@ type Asset struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 	DownloadCount   int       `json:"download_count"`
 	Size            int       `json:"size"`
+	// SHA256 is the checksum of the asset taken from checksums.txt, it is
+	// empty if the release doesn't contain checksums.
+	SHA256 string `json:"sha256,omitempty"`
 }
 
 type registryConfig struct {
@@ -187,7 +195,8 @@ type CommandRegistry struct {
 	allowedFile string
 	deniedFile  string
 
-	config registryConfig
+	config      registryConfig
+	trustConfig trustConfig
 }
 
 func NewCommandRegistry(client *github.Client, allowedFile, deniedFile string) *CommandRegistry {
@@ -204,6 +213,10 @@ func (cmd *CommandRegistry) Execute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	cmd.trustConfig, err = parseTrustConfig(trustConfigYaml)
+	if err != nil {
+		return err
+	}
 
 	reposList, err := cmd.fetchDependents(connectorSdkRepoOwnerWithName)
 	if err != nil {
@@ -244,6 +257,7 @@ func (cmd *CommandRegistry) processAllowedRepositories(ctx context.Context, repo
 		}
 
 		repoInfo.Releases = releases
+		repoInfo.Trust = cmd.trustConfig.classify(repoInfo)
 		repositories[i] = repoInfo
 	}
 
@@ -374,6 +388,7 @@ func (cmd *CommandRegistry) fetchRepoInfo(ctx context.Context, repo ghdeps.Repos
 		URL:           repoInfo.GetHTMLURL(),
 		Stargazers:    repoInfo.GetStargazersCount(),
 		Forks:         repoInfo.GetForksCount(),
+		Topics:        repoInfo.Topics,
 	}, nil
 }
 
@@ -429,9 +444,20 @@ func (cmd *CommandRegistry) fetchReleaseAssets(ctx context.Context, repo ghdeps.
 		return nil, err
 	}
 
-	var assetsList []Asset
+	var checksums map[string]string
 	for _, asset := range assets {
 		if asset.GetName() == "checksums.txt" {
+			checksums, err = cmd.fetchChecksums(ctx, repo, asset)
+			if err != nil {
+				fmt.Printf("    ⚠️  Warning: could not fetch checksums for %v: %v\n", release.GetTagName(), err)
+			}
+			break
+		}
+	}
+
+	var assetsList []Asset
+	for _, asset := range assets {
+		if asset.GetName() == "checksums.txt" || isSignatureAsset(asset.GetName()) {
 			continue
 		}
 
@@ -452,12 +478,56 @@ func (cmd *CommandRegistry) fetchReleaseAssets(ctx context.Context, repo ghdeps.
 			UpdatedAt:       asset.GetUpdatedAt().Time,
 			DownloadCount:   asset.GetDownloadCount(),
 			Size:            asset.GetSize(),
+			SHA256:          checksums[asset.GetName()],
 		})
 	}
 
 	return assetsList, nil
 }
 
+// signatureSuffixes are the suffixes of release assets containing the
+// signature or certificate of another asset (e.g. produced by cosign).
+var signatureSuffixes = []string{".sigstore.json", ".sigstore", ".bundle", ".sig", ".pem"}
+
+// isSignatureAsset returns true if the release asset contains a signature or
+// certificate of another asset, those are not listed as downloads.
+func isSignatureAsset(name string) bool {
+	return slices.ContainsFunc(signatureSuffixes, func(suffix string) bool {
+		return strings.HasSuffix(name, suffix)
+	})
+}
+
+// fetchChecksums downloads the checksums.txt release asset and returns the
+// checksums keyed by asset name.
+func (cmd *CommandRegistry) fetchChecksums(ctx context.Context, repo ghdeps.Repository, asset *github.ReleaseAsset) (map[string]string, error) {
+	rc, _, err := cmd.client.Repositories.DownloadReleaseAsset(ctx, repo.User, repo.Repo, asset.GetID(), http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return parseChecksums(rc)
+}
+
+// parseChecksums parses a checksums file in the format produced by sha256sum
+// and goreleaser: one "<sha256>  <file name>" entry per line.
+func parseChecksums(r io.Reader) (map[string]string, error) {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		// sha256sum prefixes file names with * in binary mode
+		checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read checksums: %w", err)
+	}
+	return checksums, nil
+}
+
 func (cmd *CommandRegistry) extractOSArch(asset *github.ReleaseAsset, tagName string) (string, string, bool) {
 	// example asset name:  conduit-connector-grpc-server_0.1.0_Windows_x86_64.tar.gz
 	// example tag name: v0.1.0
@@ -14,7 +14,11 @@
 
 package main
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestNewFilterExpr(t *testing.T) {
 	tests := []struct {
@@ -221,3 +225,36 @@ func TestFilterExprMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestParseChecksums(t *testing.T) {
+	in := "ABC123  conduit-connector-file_0.1.0_Linux_x86_64.tar.gz\n" +
+		"def456 *conduit-connector-file_0.1.0_Darwin_arm64.tar.gz\n" +
+		"\n" +
+		"invalid line with too many fields\n"
+
+	got, err := parseChecksums(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"conduit-connector-file_0.1.0_Linux_x86_64.tar.gz": "abc123",
+		"conduit-connector-file_0.1.0_Darwin_arm64.tar.gz": "def456",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestIsSignatureAsset(t *testing.T) {
+	for name, want := range map[string]bool{
+		"a_Linux_x86_64.tar.gz":               false,
+		"a_Linux_x86_64.tar.gz.bundle":        true,
+		"a_Linux_x86_64.tar.gz.sigstore.json": true,
+		"checksums.txt.pem":                   true,
+		"checksums.txt.sig":                   true,
+	} {
+		if got := isSignatureAsset(name); got != want {
+			t.Errorf("isSignatureAsset(%q): expected %v, got %v", name, want, got)
+		}
+	}
+}
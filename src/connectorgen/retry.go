@@ -0,0 +1,63 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+var (
+	// retryAttempts is the maximum number of times a GitHub API call is
+	// attempted before giving up.
+	retryAttempts = 4
+	// retryInitialDelay is the delay before the first retry, every following
+	// retry doubles the delay.
+	retryInitialDelay = time.Second
+)
+
+// withRetry calls fn until it succeeds or returns an error that is not
+// transient. Transient errors (5xx responses) are retried with an exponential
+// backoff, at most retryAttempts times in total.
+func withRetry[T any](ctx context.Context, fn func() (T, *github.Response, error)) (T, error) {
+	delay := retryInitialDelay
+	for attempt := 1; ; attempt++ {
+		v, _, err := fn()
+		if err == nil || !isRetryableError(err) || attempt >= retryAttempts {
+			return v, err
+		}
+
+		fmt.Printf("  🔁 Transient error (attempt %d/%d), retrying in %v: %v\n", attempt, retryAttempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return v, errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// isRetryableError returns true if the error is a GitHub API error caused by a
+// server side problem, which is likely to go away if the request is repeated.
+func isRetryableError(err error) bool {
+	var githubErr *github.ErrorResponse
+	return errors.As(err, &githubErr) &&
+		githubErr.Response != nil &&
+		githubErr.Response.StatusCode >= 500
+}
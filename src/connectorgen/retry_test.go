@@ -0,0 +1,87 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+func githubError(statusCode int) error {
+	return &github.ErrorResponse{Response: &http.Response{StatusCode: statusCode}}
+}
+
+func TestWithRetry(t *testing.T) {
+	retryInitialDelay = time.Millisecond
+	t.Cleanup(func() { retryInitialDelay = time.Second })
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name:         "success",
+			errs:         []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "transient error then success",
+			errs:         []error{githubError(502), githubError(500), nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "not found is not retried",
+			errs:         []error{githubError(404)},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "other errors are not retried",
+			errs:         []error{errors.New("boom")},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "gives up after max attempts",
+			errs:         []error{githubError(503), githubError(503), githubError(503), githubError(503), nil},
+			wantErr:      true,
+			wantAttempts: retryAttempts,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			_, err := withRetry(context.Background(), func() (string, *github.Response, error) {
+				err := tt.errs[attempts]
+				attempts++
+				return "", nil, err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("withRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("withRetry() attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
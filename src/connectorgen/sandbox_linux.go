@@ -0,0 +1,115 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// sandboxCommand changes cmd so that it runs in a sandbox. Instead of the
+// binary, connectorgen re-executes itself in new user, mount, network, PID,
+// IPC and UTS namespaces. There, sandboxInit makes the file system read-only
+// except for workDir, hides the home directory and /proc of the host, then
+// replaces itself with the binary. The new network namespace has no
+// interfaces besides a loopback that is down, so the binary has no network
+// access. The plugin connection uses a unix socket in workDir.
+func sandboxCommand(cmd *exec.Cmd, workDir string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the connectorgen executable: %w", err)
+	}
+	// the home directory is an argument, as the binary gets a clean environment
+	home, _ := os.UserHomeDir()
+
+	cmd.Args = append([]string{self, sandboxInitArg, workDir, home, cmd.Path}, cmd.Args[1:]...)
+	cmd.Path = self
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET |
+			syscall.CLONE_NEWPID | syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS,
+		// the binary runs as root in the sandbox, which is the current user
+		// outside of it
+		UidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}},
+		GidMappings: []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}},
+		Pdeathsig:   syscall.SIGKILL,
+	}
+	return nil
+}
+
+// sandboxInit runs in the namespaces created by sandboxCommand. It expects
+// the work directory, the home directory, the binary and its arguments, sets
+// up the mounts and starts the binary. It never returns.
+func sandboxInit(args []string) {
+	if len(args) < 3 {
+		fmt.Fprintln(os.Stderr, "sandbox: expected work directory, home directory and binary")
+		os.Exit(1)
+	}
+	workDir, home, binary := args[0], args[1], args[2]
+
+	if err := sandboxMounts(workDir, home); err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+		os.Exit(1)
+	}
+	err := syscall.Exec(binary, args[2:], os.Environ())
+	fmt.Fprintf(os.Stderr, "sandbox: failed to start %s: %v\n", binary, err)
+	os.Exit(1)
+}
+
+func sandboxMounts(workDir, home string) error {
+	// Mounts must not propagate back to the host
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make mounts private: %w", err)
+	}
+	// The environment of processes outside the sandbox (e.g. GITHUB_TOKEN of
+	// connectorgen) must not be readable through the /proc of the host
+	if err := unix.Mount("proc", "/proc", "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("failed to mount /proc: %w", err)
+	}
+	if home != "" && !isWithin(workDir, home) {
+		if err := unix.Mount("tmpfs", home, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "size=1m"); err != nil {
+			return fmt.Errorf("failed to hide home directory: %w", err)
+		}
+	}
+	// workDir gets its own mount, so it can stay writable
+	if err := unix.Mount(workDir, workDir, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("failed to mount work directory: %w", err)
+	}
+	if err := unix.MountSetattr(-1, "/", unix.AT_RECURSIVE, &unix.MountAttr{
+		Attr_set: unix.MOUNT_ATTR_RDONLY | unix.MOUNT_ATTR_NOSUID,
+	}); err != nil {
+		return fmt.Errorf("failed to make file system read-only: %w", err)
+	}
+	if err := unix.MountSetattr(-1, workDir, unix.AT_RECURSIVE, &unix.MountAttr{
+		Attr_clr: unix.MOUNT_ATTR_RDONLY,
+	}); err != nil {
+		return fmt.Errorf("failed to make work directory writable: %w", err)
+	}
+	// The working directory still points to the read-only mount
+	return os.Chdir(workDir)
+}
+
+// isWithin returns true if path is dir or inside of it.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,173 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-connector-protocol/pconnector"
+	"github.com/conduitio/conduit-connector-protocol/pconnector/server"
+)
+
+// testConnectorName is the name under which the test binary acts as a
+// connector plugin, see TestMain.
+const testConnectorName = "test-connector"
+
+func TestMain(m *testing.M) {
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == sandboxInitArg:
+		sandboxInit(os.Args[2:])
+	case filepath.Base(os.Args[0]) == testConnectorName:
+		serveTestConnector()
+	}
+	os.Exit(m.Run())
+}
+
+// serveTestConnector serves a connector plugin, the summary of its
+// specification lists the sandbox restrictions that don't hold.
+func serveTestConnector() {
+	var problems []string
+	if err := os.WriteFile("probe", []byte("probe"), 0o644); err != nil {
+		problems = append(problems, "can't write to work directory")
+	}
+	if err := os.WriteFile("/probe", []byte("probe"), 0o644); err == nil {
+		problems = append(problems, "can write to /")
+	}
+	if os.Getpid() != 1 {
+		problems = append(problems, "can see processes outside of the sandbox")
+	}
+	if os.Getenv("GITHUB_TOKEN") != "" {
+		problems = append(problems, "GITHUB_TOKEN is set")
+	}
+
+	spec := pconnector.Specification{
+		Name:    testConnectorName,
+		Summary: strings.Join(problems, ", "),
+		Version: "v0.1.0",
+	}
+	err := server.Serve(
+		func() pconnector.SpecifierPlugin { return testSpecifier{spec: spec} },
+		func() pconnector.SourcePlugin { return nil },
+		func() pconnector.DestinationPlugin { return nil },
+	)
+	if err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+type testSpecifier struct {
+	spec pconnector.Specification
+}
+
+func (s testSpecifier) Specify(context.Context, pconnector.SpecifierSpecifyRequest) (pconnector.SpecifierSpecifyResponse, error) {
+	return pconnector.SpecifierSpecifyResponse{Specification: s.spec}, nil
+}
+
+// requireSandbox skips the test if unprivileged user namespaces aren't
+// available.
+func requireSandbox(t *testing.T) {
+	t.Helper()
+	cmd := exec.Command("/bin/true")
+	if err := sandboxCommand(cmd, t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("sandbox not available: %v %s", err, out)
+	}
+}
+
+func TestSandboxCommand(t *testing.T) {
+	requireSandbox(t)
+
+	home := t.TempDir()
+	if err := os.WriteFile(filepath.Join(home, "secret"), []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		name   string
+		script string
+	}{{
+		name:   "work directory is writable",
+		script: `echo probe > probe && test -s "$WORK_DIR/probe"`,
+	}, {
+		name:   "file system is read-only",
+		script: `! touch /probe 2>/dev/null && ! touch /tmp/probe 2>/dev/null`,
+	}, {
+		name:   "file system is readable",
+		script: `test -s /etc/passwd`,
+	}, {
+		name:   "home directory is hidden",
+		script: `test -d "$HOME" && test ! -e "$HOME/secret"`,
+	}, {
+		name:   "processes are hidden",
+		script: `test $$ -eq 1 && test ! -e "/proc/$PARENT_PID"`,
+	}, {
+		// /proc/net/dev has two header lines, followed by the interfaces
+		name:   "no network interfaces",
+		script: `test "$(tail -n +3 /proc/net/dev | grep -vc 'lo:')" -eq 0`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workDir := t.TempDir()
+			cmd := exec.Command("/bin/sh", "-c", tt.script)
+			cmd.Dir = workDir
+			cmd.Env = []string{
+				"PATH=/usr/bin:/bin",
+				"HOME=" + home,
+				"WORK_DIR=" + workDir,
+				"PARENT_PID=" + strconv.Itoa(os.Getpid()),
+			}
+			if err := sandboxCommand(cmd, workDir); err != nil {
+				t.Fatal(err)
+			}
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("script failed: %v\n%s", err, out)
+			}
+		})
+	}
+}
+
+func TestSpecifyBinary(t *testing.T) {
+	requireSandbox(t)
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	workDir := t.TempDir()
+	binary := filepath.Join(workDir, testConnectorName)
+	if err := os.Symlink(os.Args[0], binary); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := specifyBinary(context.Background(), binary, workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Name != testConnectorName {
+		t.Errorf("expected specification of %s, got %+v", testConnectorName, spec)
+	}
+	if spec.Summary != "" {
+		t.Errorf("sandbox restrictions don't hold: %s", spec.Summary)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/conduitio/genfuncs"
+)
+
+// schemasURL is the URL under which the connector schemas are published.
+const schemasURL = siteURL + "/schemas/connectors/"
+
+// CommandSchema generates a JSON Schema for the settings of the source and
+// destination of each connector, so editors can validate pipeline
+// configuration files.
+type CommandSchema struct {
+	connectorsFile string
+	specsFolder    string
+	pageIndexFile  string
+	outputFolder   string
+}
+
+func NewCommandSchema(connectorsFile, specsFolder, pageIndexFile, outputFolder string) *CommandSchema {
+	return &CommandSchema{
+		connectorsFile: connectorsFile,
+		specsFolder:    specsFolder,
+		pageIndexFile:  pageIndexFile,
+		outputFolder:   outputFolder,
+	}
+}
+
+func (cmd *CommandSchema) Execute(context.Context) error {
+	fmt.Printf("👀 Reading %s ...\n", cmd.connectorsFile)
+
+	data, err := os.ReadFile(cmd.connectorsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	var repositories []Repository
+	if err := json.Unmarshal(data, &repositories); err != nil {
+		return fmt.Errorf("failed to parse JSON input: %w", err)
+	}
+
+	index, err := readPageIndex(cmd.pageIndexFile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(cmd.outputFolder, 0755); err != nil {
+		return fmt.Errorf("failed to create output folder: %w", err)
+	}
+
+	// loading specifications works the same as for pages
+	docs := NewCommandDocs(cmd.connectorsFile, cmd.specsFolder, cmd.pageIndexFile, nil)
+
+	count := 0
+	for _, repo := range repositories {
+		latest, ok := docs.loadSpecifications(repo)["latest"]
+		if !ok {
+			continue
+		}
+		fmt.Printf("\n🕵  Processing repository %v\n", repo.NameWithOwner)
+
+		// schemas are named like the connector page, so names are unique
+		slug, ok := index.Pages[repo.NameWithOwner]
+		if !ok {
+			slug = genfuncs.Slugify(latest.Name)
+		}
+
+		for _, plugin := range []struct {
+			name string
+			spec *PluginSpecification
+		}{
+			{name: "source", spec: latest.Source},
+			{name: "destination", spec: latest.Destination},
+		} {
+			if plugin.spec == nil {
+				continue
+			}
+			file := slug + "." + plugin.name + ".schema.json"
+			schema, err := pluginSchema(schemasURL+file, latest, plugin.name, plugin.spec)
+			if err != nil {
+				fmt.Printf("  ⚠️  Warning: skipping %s schema: %v\n", plugin.name, err)
+				continue
+			}
+			if err := writeSchema(filepath.Join(cmd.outputFolder, file), schema); err != nil {
+				return err
+			}
+			count++
+		}
+	}
+
+	fmt.Printf("\n✅ Generated %d schemas\n", count)
+	return nil
+}
+
+// pluginSchema returns the JSON Schema of the settings of a connector plugin.
+func pluginSchema(id string, spec Specification, pluginType string, plugin *PluginSpecification) (*genfuncs.JSONSchema, error) {
+	params := make([]genfuncs.Parameter, len(plugin.Parameters))
+	for i, p := range plugin.Parameters {
+		params[i] = genfuncs.Parameter{
+			Name:        p.Name,
+			Type:        p.Type,
+			Default:     p.Default,
+			Description: string(rewriteDomain([]byte(p.Description))),
+		}
+		for _, v := range p.Validations {
+			params[i].Validations = append(params[i].Validations, genfuncs.Validation{Type: v.Type, Value: v.Value})
+		}
+	}
+	title := fmt.Sprintf("%s %s", spec.Name, pluginType)
+	return genfuncs.SettingsSchema(id, title, string(rewriteDomain([]byte(spec.Summary))), params)
+}
+
+func writeSchema(path string, schema *genfuncs.JSONSchema) error {
+	fmt.Printf("💾 Writing %s ...\n", path)
+	raw, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	if err := os.WriteFile(path, append(raw, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPluginSchema(t *testing.T) {
+	spec := Specification{Name: "demo", Summary: "See conduit.io"}
+	plugin := &PluginSpecification{Parameters: []Parameter{{
+		Name:        "url",
+		Type:        "string",
+		Description: "URL, see https://conduit.io.",
+		Validations: []Validation{{Type: "required"}},
+	}, {
+		Name:        "sdk.batch.size",
+		Type:        "int",
+		Default:     "0",
+		Validations: []Validation{{Type: "greater-than", Value: "-1"}},
+	}}}
+
+	schema, err := pluginSchema(schemasURL+"demo.source.schema.json", spec, "source", plugin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"$id":"https://conduitdata.io/schemas/connectors/demo.source.schema.json"`,
+		`"title":"demo source","description":"See conduitdata.io"`,
+		`"url":{"description":"URL, see https://conduitdata.io.","type":"string"}`,
+		`"default":0`,
+		`"exclusiveMinimum":-1`,
+		`"additionalProperties":false,"required":["url"]`,
+	} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("expected schema to contain %s, got %s", want, raw)
+		}
+	}
+
+	plugin.Parameters[0].Type = "uuid"
+	if _, err := pluginSchema("", spec, "source", plugin); err == nil {
+		t.Error("expected error for unknown parameter type")
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conduitio/conduit-commons/config"
+)
+
+// Specification is the internal model of a connector specification. It is
+// decoded from any supported connector.yaml format version, see
+// decodeConnectorYAML.
+type Specification struct {
+	Name        string
+	Summary     string
+	Description string
+	Version     string
+	Author      string
+
+	// Source and Destination are nil if the connector doesn't implement the
+	// corresponding plugin.
+	Source      *PluginSpecification
+	Destination *PluginSpecification
+}
+
+// PluginSpecification describes the source or destination of a connector.
+type PluginSpecification struct {
+	Parameters []Parameter
+}
+
+// Parameter describes a single configuration parameter.
+type Parameter struct {
+	Name        string
+	Description string
+	Type        string
+	Default     string
+	Validations []Validation
+}
+
+// Validation describes a validation applied to a parameter value.
+type Validation struct {
+	Type  string
+	Value string
+}
+
+// IsRequired returns true if the parameter has a required validation.
+func (p Parameter) IsRequired() bool {
+	for _, v := range p.Validations {
+		if v.Type == config.ValidationTypeRequired.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// Describe returns a human-readable description of the validation.
+func (v Validation) Describe() string {
+	switch v.Type {
+	case config.ValidationTypeRequired.String():
+		return "required"
+	case config.ValidationTypeGreaterThan.String():
+		return "greater than " + v.Value
+	case config.ValidationTypeLessThan.String():
+		return "less than " + v.Value
+	case config.ValidationTypeInclusion.String():
+		return "one of: " + strings.Join(splitValidationList(v.Value), ", ")
+	case config.ValidationTypeExclusion.String():
+		return "not one of: " + strings.Join(splitValidationList(v.Value), ", ")
+	case config.ValidationTypeRegex.String():
+		return "matches regex " + v.Value
+	default:
+		return strings.TrimSpace(v.Type + " " + v.Value)
+	}
+}
+
+// splitValidationList splits the comma separated value of an inclusion or
+// exclusion validation.
+func splitValidationList(s string) []string {
+	list := strings.Split(s, ",")
+	for i, item := range list {
+		list[i] = strings.TrimSpace(item)
+	}
+	return list
+}
+
+// parameterTypeToConfig converts the parameter type to the type known by
+// conduit-commons.
+func parameterTypeToConfig(t string) (config.ParameterType, error) {
+	for _, pt := range []config.ParameterType{
+		config.ParameterTypeString,
+		config.ParameterTypeInt,
+		config.ParameterTypeFloat,
+		config.ParameterTypeBool,
+		config.ParameterTypeFile,
+		config.ParameterTypeDuration,
+	} {
+		if pt.String() == t {
+			return pt, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown parameter type %q", t)
+}
+
+// toConfig converts the validation to the validation known by conduit-commons,
+// so it can be used to validate values.
+func (v Validation) toConfig() (config.Validation, error) {
+	switch v.Type {
+	case config.ValidationTypeRequired.String():
+		return config.ValidationRequired{}, nil
+	case config.ValidationTypeGreaterThan.String():
+		val, err := strconv.ParseFloat(v.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %w", v.Type, v.Value, err)
+		}
+		return config.ValidationGreaterThan{V: val}, nil
+	case config.ValidationTypeLessThan.String():
+		val, err := strconv.ParseFloat(v.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %w", v.Type, v.Value, err)
+		}
+		return config.ValidationLessThan{V: val}, nil
+	case config.ValidationTypeInclusion.String():
+		return config.ValidationInclusion{List: splitValidationList(v.Value)}, nil
+	case config.ValidationTypeExclusion.String():
+		return config.ValidationExclusion{List: splitValidationList(v.Value)}, nil
+	case config.ValidationTypeRegex.String():
+		regex, err := regexp.Compile(v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %w", v.Type, v.Value, err)
+		}
+		return config.ValidationRegex{Regex: regex}, nil
+	default:
+		return nil, fmt.Errorf("unknown validation type %q", v.Type)
+	}
+}
+
+// validateValue checks if the value is valid for the parameter type.
+func validateValue(t config.ParameterType, value string) error {
+	var err error
+	// string and file values are always valid
+	switch t {
+	case config.ParameterTypeInt:
+		_, err = strconv.Atoi(value)
+	case config.ParameterTypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case config.ParameterTypeBool:
+		_, err = strconv.ParseBool(value)
+	case config.ParameterTypeDuration:
+		_, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return fmt.Errorf("%q is not a valid %s", value, t)
+	}
+	return nil
+}
+
+// Validate checks the specification for problems. The tag is the release tag
+// the specification was fetched from, it is compared with the version in the
+// specification. All found problems are returned.
+func (spec Specification) Validate(tag string) []error {
+	var errs []error
+
+	if spec.Name == "" {
+		errs = append(errs, errors.New("specification.name is missing"))
+	}
+	if tag != "" && strings.TrimPrefix(spec.Version, "v") != strings.TrimPrefix(tag, "v") {
+		errs = append(errs, fmt.Errorf("specification.version %q does not match release tag %q", spec.Version, tag))
+	}
+	if spec.Source == nil && spec.Destination == nil {
+		errs = append(errs, errors.New("specification contains neither a source nor a destination"))
+	}
+
+	if spec.Source != nil {
+		errs = append(errs, validateParameters("specification.source", spec.Source.Parameters)...)
+	}
+	if spec.Destination != nil {
+		errs = append(errs, validateParameters("specification.destination", spec.Destination.Parameters)...)
+	}
+
+	return errs
+}
+
+func validateParameters(path string, params []Parameter) []error {
+	var errs []error
+	seen := make(map[string]bool, len(params))
+	for i, p := range params {
+		paramPath := fmt.Sprintf("%s.parameters[%d]", path, i)
+		if p.Name == "" {
+			errs = append(errs, fmt.Errorf("%s: name is missing", paramPath))
+		} else {
+			paramPath = fmt.Sprintf("%s.parameters[%s]", path, p.Name)
+			if seen[p.Name] {
+				errs = append(errs, fmt.Errorf("%s: duplicate parameter", paramPath))
+			}
+			seen[p.Name] = true
+		}
+
+		errs = append(errs, validateParameter(paramPath, p)...)
+	}
+	return errs
+}
+
+func validateParameter(path string, p Parameter) []error {
+	var errs []error
+
+	// Validations are only applied to non-empty defaults of the right type,
+	// an empty default means the parameter has no default value.
+	checkDefault := p.Default != ""
+	paramType, err := parameterTypeToConfig(p.Type)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", path, err))
+	} else if checkDefault {
+		if err := validateValue(paramType, p.Default); err != nil {
+			errs = append(errs, fmt.Errorf("%s: default value %w", path, err))
+			checkDefault = false
+		}
+	}
+
+	for _, v := range p.Validations {
+		cv, err := v.toConfig()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		if !checkDefault {
+			continue
+		}
+		if err := cv.Validate(p.Default); err != nil {
+			errs = append(errs, fmt.Errorf("%s: default value %q violates %s validation: %w", path, p.Default, v.Type, err))
+		}
+	}
+
+	return errs
+}
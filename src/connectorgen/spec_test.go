@@ -0,0 +1,123 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpecificationValidate(t *testing.T) {
+	valid := func() Specification {
+		return Specification{
+			Name:    "file",
+			Version: "v0.1.0",
+			Source: &PluginSpecification{
+				Parameters: []Parameter{
+					{
+						Name:        "path",
+						Type:        "string",
+						Validations: []Validation{{Type: "required"}},
+					},
+					{
+						Name:        "sdk.batch.size",
+						Type:        "int",
+						Default:     "0",
+						Validations: []Validation{{Type: "greater-than", Value: "-1"}},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*Specification)
+		wantErr []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*Specification) {},
+		},
+		{
+			name:    "missing name",
+			mutate:  func(s *Specification) { s.Name = "" },
+			wantErr: []string{"specification.name is missing"},
+		},
+		{
+			name:    "version mismatch",
+			mutate:  func(s *Specification) { s.Version = "(devel)" },
+			wantErr: []string{`specification.version "(devel)" does not match release tag "v0.1.0"`},
+		},
+		{
+			name:    "invalid parameter type",
+			mutate:  func(s *Specification) { s.Source.Parameters[0].Type = "text" },
+			wantErr: []string{`specification.source.parameters[path]: unknown parameter type "text"`},
+		},
+		{
+			name:    "default does not match type",
+			mutate:  func(s *Specification) { s.Source.Parameters[1].Default = "abc" },
+			wantErr: []string{`specification.source.parameters[sdk.batch.size]: default value "abc" is not a valid int`},
+		},
+		{
+			name:    "default violates validation",
+			mutate:  func(s *Specification) { s.Source.Parameters[1].Default = "-5" },
+			wantErr: []string{`default value "-5" violates greater-than validation`},
+		},
+		{
+			name: "unknown validation",
+			mutate: func(s *Specification) {
+				s.Source.Parameters[0].Validations[0].Type = "unique"
+			},
+			wantErr: []string{`unknown validation type "unique"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := valid()
+			tt.mutate(&spec)
+
+			errs := spec.Validate("v0.1.0")
+			if len(errs) != len(tt.wantErr) {
+				t.Fatalf("Validate() returned %d errors, want %d: %v", len(errs), len(tt.wantErr), errs)
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), tt.wantErr[i]) {
+					t.Errorf("Validate() error %d = %q, want it to contain %q", i, err, tt.wantErr[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidationDescribe(t *testing.T) {
+	tests := []struct {
+		validation Validation
+		want       string
+	}{
+		{validation: Validation{Type: "required"}, want: "required"},
+		{validation: Validation{Type: "greater-than", Value: "0"}, want: "greater than 0"},
+		{validation: Validation{Type: "less-than", Value: "10"}, want: "less than 10"},
+		{validation: Validation{Type: "inclusion", Value: "a,b, c"}, want: "one of: a, b, c"},
+		{validation: Validation{Type: "exclusion", Value: "x"}, want: "not one of: x"},
+		{validation: Validation{Type: "regex", Value: "^[a-z]+$"}, want: "matches regex ^[a-z]+$"},
+	}
+	for _, tt := range tests {
+		if got := tt.validation.Describe(); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.validation.Type, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/yaml/v3"
+)
+
+// latestConnectorYAMLVersion is the newest connector.yaml format version known
+// to connectorgen. Specifications derived from binaries are written in this
+// version.
+const latestConnectorYAMLVersion = "1.0"
+
+var errUnknownConnectorYAMLVersion = errors.New("unknown connector.yaml version")
+
+// connectorYAMLFormat is a versioned connector.yaml format that can be
+// decoded into the internal Specification model.
+type connectorYAMLFormat interface {
+	toSpecification() Specification
+}
+
+// connectorYAMLDecoders contains a decoder for each supported major version of
+// the connector.yaml format. Minor versions only add fields and stay
+// compatible, so they are decoded using their major version decoder. Adding
+// support for a new major version means adding a type implementing
+// connectorYAMLFormat and registering it here.
+var connectorYAMLDecoders = map[int]func() connectorYAMLFormat{
+	1: func() connectorYAMLFormat { return &connectorYAMLV1{} },
+}
+
+// decodeConnectorYAML decodes the content of a connector.yaml file in any
+// supported format version into the internal model.
+func decodeConnectorYAML(raw []byte) (Specification, error) {
+	var header struct {
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(raw, &header); err != nil {
+		return Specification{}, fmt.Errorf("failed to unmarshal connector.yaml: %w", err)
+	}
+	if header.Version == "" {
+		return Specification{}, fmt.Errorf("%w: version field is missing", errUnknownConnectorYAMLVersion)
+	}
+
+	major, err := majorVersion(header.Version)
+	if err != nil {
+		return Specification{}, fmt.Errorf("%w %q: %w", errUnknownConnectorYAMLVersion, header.Version, err)
+	}
+	newFormat, ok := connectorYAMLDecoders[major]
+	if !ok {
+		return Specification{}, fmt.Errorf("%w %q (latest supported version is %s)",
+			errUnknownConnectorYAMLVersion, header.Version, latestConnectorYAMLVersion)
+	}
+
+	format := newFormat()
+	if err := yaml.Unmarshal(raw, format); err != nil {
+		return Specification{}, fmt.Errorf("failed to unmarshal connector.yaml version %s: %w", header.Version, err)
+	}
+	return format.toSpecification(), nil
+}
+
+// encodeConnectorYAML encodes the specification in the latest connector.yaml
+// format version.
+func encodeConnectorYAML(spec Specification) ([]byte, error) {
+	return yaml.Marshal(connectorYAMLV1FromSpecification(spec))
+}
+
+func majorVersion(version string) (int, error) {
+	major, _, _ := strings.Cut(version, ".")
+	return strconv.Atoi(major)
+}
+
+// -- VERSION 1 ----------------------------------------------------------------
+
+type connectorYAMLV1 struct {
+	Version       string          `yaml:"version"`
+	Specification specificationV1 `yaml:"specification"`
+}
+
+type specificationV1 struct {
+	Name        string `yaml:"name"`
+	Summary     string `yaml:"summary"`
+	Description string `yaml:"description"`
+	Version     string `yaml:"version"`
+	Author      string `yaml:"author"`
+
+	Source      *pluginV1 `yaml:"source,omitempty"`
+	Destination *pluginV1 `yaml:"destination,omitempty"`
+}
+
+type pluginV1 struct {
+	Parameters []parameterV1 `yaml:"parameters"`
+}
+
+type parameterV1 struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Type        string         `yaml:"type"`
+	Default     string         `yaml:"default"`
+	Validations []validationV1 `yaml:"validations"`
+}
+
+type validationV1 struct {
+	Type  string `yaml:"type"`
+	Value string `yaml:"value"`
+}
+
+func (c *connectorYAMLV1) toSpecification() Specification {
+	toPlugin := func(p *pluginV1) *PluginSpecification {
+		if p == nil {
+			return nil
+		}
+		params := make([]Parameter, len(p.Parameters))
+		for i, param := range p.Parameters {
+			validations := make([]Validation, len(param.Validations))
+			for j, v := range param.Validations {
+				validations[j] = Validation(v)
+			}
+			params[i] = Parameter{
+				Name:        param.Name,
+				Description: param.Description,
+				Type:        param.Type,
+				Default:     param.Default,
+				Validations: validations,
+			}
+		}
+		return &PluginSpecification{Parameters: params}
+	}
+
+	return Specification{
+		Name:        c.Specification.Name,
+		Summary:     c.Specification.Summary,
+		Description: c.Specification.Description,
+		Version:     c.Specification.Version,
+		Author:      c.Specification.Author,
+		Source:      toPlugin(c.Specification.Source),
+		Destination: toPlugin(c.Specification.Destination),
+	}
+}
+
+func connectorYAMLV1FromSpecification(spec Specification) connectorYAMLV1 {
+	fromPlugin := func(p *PluginSpecification) *pluginV1 {
+		if p == nil {
+			return nil
+		}
+		params := make([]parameterV1, len(p.Parameters))
+		for i, param := range p.Parameters {
+			validations := make([]validationV1, len(param.Validations))
+			for j, v := range param.Validations {
+				validations[j] = validationV1(v)
+			}
+			params[i] = parameterV1{
+				Name:        param.Name,
+				Description: param.Description,
+				Type:        param.Type,
+				Default:     param.Default,
+				Validations: validations,
+			}
+		}
+		return &pluginV1{Parameters: params}
+	}
+
+	return connectorYAMLV1{
+		Version: latestConnectorYAMLVersion,
+		Specification: specificationV1{
+			Name:        spec.Name,
+			Summary:     spec.Summary,
+			Description: spec.Description,
+			Version:     spec.Version,
+			Author:      spec.Author,
+			Source:      fromPlugin(spec.Source),
+			Destination: fromPlugin(spec.Destination),
+		},
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeConnectorYAML(t *testing.T) {
+	want := Specification{
+		Name:    "file",
+		Summary: "A file source.",
+		Version: "v0.1.0",
+		Author:  "Meroxa, Inc.",
+		Source: &PluginSpecification{
+			Parameters: []Parameter{{
+				Name:        "path",
+				Description: "Path to the file.",
+				Type:        "string",
+				Validations: []Validation{{Type: "required"}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		version string
+		wantErr error
+	}{
+		{name: "1.0", version: `"1.0"`},
+		{name: "newer minor version", version: `"1.3"`},
+		{name: "unknown major version", version: `"2.0"`, wantErr: errUnknownConnectorYAMLVersion},
+		{name: "invalid version", version: `"latest"`, wantErr: errUnknownConnectorYAMLVersion},
+		{name: "missing version", version: `""`, wantErr: errUnknownConnectorYAMLVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := []byte(`version: ` + tt.version + `
+specification:
+  name: file
+  summary: A file source.
+  version: v0.1.0
+  author: Meroxa, Inc.
+  source:
+    parameters:
+      - name: path
+        description: Path to the file.
+        type: string
+        default: ""
+        validations:
+          - type: required
+            value: ""
+`)
+			got, err := decodeConnectorYAML(raw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("decodeConnectorYAML() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("decodeConnectorYAML() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeConnectorYAMLRoundTrip(t *testing.T) {
+	spec := Specification{
+		Name: "file",
+		Destination: &PluginSpecification{
+			Parameters: []Parameter{{
+				Name:        "path",
+				Type:        "string",
+				Validations: []Validation{{Type: "required"}},
+			}},
+		},
+	}
+
+	raw, err := encodeConnectorYAML(spec)
+	if err != nil {
+		t.Fatalf("encodeConnectorYAML() error = %v", err)
+	}
+	got, err := decodeConnectorYAML(raw)
+	if err != nil {
+		t.Fatalf("decodeConnectorYAML() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, spec) {
+		t.Errorf("round trip = %+v, want %+v", got, spec)
+	}
+}
@@ -16,10 +16,10 @@ package main
 
 import (
 	"context"
+	_ "embed"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -32,27 +32,64 @@ import (
 type Metadata struct {
 	FetchedAt time.Time `yaml:"fetchedAt"`
 	CommitSHA string    `yaml:"commitSHA"`
+	// DerivedFrom contains the URL of the release asset, if connector.yaml was
+	// not found in the repository and was instead derived from the connector
+	// binary.
+	DerivedFrom string `yaml:"derivedFrom,omitempty"`
 }
 
-var errNoConnectorYAML = errors.New("no connector.yaml found")
+//go:embed specifications-config.yaml
+var specificationsConfigYaml []byte
+
+// defaultConnectorYAMLPath is the path of connector.yaml in repositories that
+// are not configured otherwise.
+const defaultConnectorYAMLPath = "connector.yaml"
+
+var (
+	errNoConnectorYAML = errors.New("no connector.yaml found")
+	errTruncatedTree   = errors.New("tree is truncated")
+)
+
+type specificationsConfig struct {
+	// Paths maps lowercase <owner>/<repo> to the path of connector.yaml.
+	Paths map[string]string `yaml:"paths"`
+}
+
+// connectorYAMLPath returns the path of connector.yaml in the given repository.
+func (c specificationsConfig) connectorYAMLPath(nameWithOwner string) string {
+	if path, ok := c.Paths[strings.ToLower(nameWithOwner)]; ok {
+		return path
+	}
+	return defaultConnectorYAMLPath
+}
 
 type CommandSpecifications struct {
 	client         *github.Client
 	connectorsFile string
 	outputFolder   string
 	force          bool
+	binaryFallback bool
+
+	config specificationsConfig
 }
 
-func NewCommandSpecifications(client *github.Client, connectorsFile, outputFolder string, force bool) *CommandSpecifications {
+func NewCommandSpecifications(client *github.Client, connectorsFile, outputFolder string, force, binaryFallback bool) *CommandSpecifications {
 	return &CommandSpecifications{
 		client:         client,
 		connectorsFile: connectorsFile,
 		outputFolder:   outputFolder,
 		force:          force,
+		binaryFallback: binaryFallback,
 	}
 }
 
 func (cmd *CommandSpecifications) Execute(ctx context.Context) error {
+	var err error
+	cmd.config, err = cmd.parseConfig()
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("👀 Reading %s ...\n", cmd.connectorsFile)
 
 	// Read and parse the input JSON file
@@ -71,6 +108,8 @@ func (cmd *CommandSpecifications) Execute(ctx context.Context) error {
 		return fmt.Errorf("failed to create output folder: %w", err)
 	}
 
+	var summary specificationsSummary
+
 	// Process each repository
 	for _, repo := range repositories {
 		fmt.Printf("\n🕵  Processing repository %v\n", repo.NameWithOwner)
@@ -88,66 +127,238 @@ func (cmd *CommandSpecifications) Execute(ctx context.Context) error {
 		}
 
 		for _, release := range repo.Releases {
-			// Create folder path
-			folderPath := filepath.Join(cmd.outputFolder, "github.com", owner, repoName+"@"+release.TagName)
-			if err := os.MkdirAll(folderPath, 0755); err != nil {
-				return fmt.Errorf("failed to create folder %s: %w", folderPath, err)
-			}
-
-			// Check if connector.yaml already exists
-			commitSHA, err := cmd.getCommitForTag(ctx, owner, repoName, release.TagName)
+			result, err := cmd.processRelease(ctx, owner, repoName, release)
 			if err != nil {
-				fmt.Printf("  ❌ Error: failed to get commit for tag %s: %v\n", release.TagName, err)
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				fmt.Printf("  ❌ Error: %s@%s: %v\n", repo.NameWithOwner, release.TagName, err)
+				summary.failures = append(summary.failures, releaseFailure{
+					repo: repo.NameWithOwner,
+					tag:  release.TagName,
+					err:  err,
+				})
 				continue
 			}
+			summary.add(result)
+		}
+	}
 
-			if !cmd.force && cmd.hasKnownCommitSHA(folderPath, commitSHA) {
-				fmt.Printf("  ✅ Already have latest connector.yaml for %s@%s, skipping\n", repo.NameWithOwner, release.TagName)
-				continue
-			}
+	return summary.report()
+}
 
-			// Fetch and write connector.yaml
-			fmt.Printf("  📥 Fetching connector.yaml for tag %s...\n", release.TagName)
-			yamlContent, err := cmd.fetchBlob(ctx, owner, repoName, commitSHA, "connector.yaml")
-			if errors.Is(err, errNoConnectorYAML) {
-				fmt.Printf("  ⚠️  Warning: no connector.yaml found for %s@%s\n",
-					repo.NameWithOwner, release.TagName)
-				// Still write the metadata file
-			} else if err != nil {
-				fmt.Printf("  ❌ Error: failed to fetch connector.yaml for %s@%s: %v\n",
-					repo.NameWithOwner, release.TagName, err)
-				continue
-			}
+// deriveSpecification extracts the specification from the release binary. It
+// returns the connector.yaml content and the URL of the asset it was derived
+// from.
+func (cmd *CommandSpecifications) deriveSpecification(ctx context.Context, repoName string, release Release) ([]byte, string, error) {
+	asset, ok := binaryAsset(release)
+	if !ok {
+		return nil, "", errNoBinaryAsset
+	}
 
-			// Write connector.yaml
-			if yamlContent != nil {
-				connectorYamlPath := filepath.Join(folderPath, "connector.yaml")
-				if err := os.WriteFile(connectorYamlPath, rewriteDomain(yamlContent), 0644); err != nil {
-					return fmt.Errorf("failed to write connector.yaml for %s@%s: %w",
-						repo.NameWithOwner, release.TagName, err)
-				}
+	fmt.Printf("  🔧 Deriving connector.yaml from release asset %s...\n", asset.Name)
+	yamlContent, err := cmd.specificationFromBinary(ctx, repoName, asset)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to derive connector.yaml from %s: %w", asset.Name, err)
+	}
+
+	return yamlContent, githubDownloadURL(asset), nil
+}
+
+func (cmd *CommandSpecifications) parseConfig() (specificationsConfig, error) {
+	var cfg specificationsConfig
+	if err := yaml.Unmarshal(specificationsConfigYaml, &cfg); err != nil {
+		return specificationsConfig{}, fmt.Errorf("failed to parse specifications-config.yaml: %w", err)
+	}
+
+	// Normalize keys, so lookups are case-insensitive
+	paths := make(map[string]string, len(cfg.Paths))
+	for repo, path := range cfg.Paths {
+		if _, _, ok := strings.Cut(repo, "/"); !ok || path == "" {
+			return specificationsConfig{}, fmt.Errorf("invalid connector.yaml path entry %q: %q", repo, path)
+		}
+		paths[strings.ToLower(repo)] = strings.TrimPrefix(path, "/")
+	}
+	cfg.Paths = paths
+
+	return cfg, nil
+}
+
+// releaseResult describes the outcome of successfully processing a release.
+type releaseResult int
+
+const (
+	releaseFetched releaseResult = iota
+	releaseUpToDate
+	releaseDerived
+	releaseNoConnectorYAML
+)
+
+// releaseFailure describes a release that could not be processed.
+type releaseFailure struct {
+	repo string
+	tag  string
+	err  error
+}
+
+// specificationsSummary collects the outcome of processing all releases, so
+// that a single failing release doesn't abort the whole run.
+type specificationsSummary struct {
+	fetched         int
+	upToDate        int
+	derived         int
+	noConnectorYAML int
+	failures        []releaseFailure
+}
+
+func (s *specificationsSummary) add(result releaseResult) {
+	switch result {
+	case releaseFetched:
+		s.fetched++
+	case releaseUpToDate:
+		s.upToDate++
+	case releaseDerived:
+		s.derived++
+	case releaseNoConnectorYAML:
+		s.noConnectorYAML++
+	}
+}
+
+// report prints the summary and returns an error if any release failed.
+func (s *specificationsSummary) report() error {
+	fmt.Printf("\n📊 Summary: %d fetched, %d up to date, %d derived from binaries, %d without connector.yaml, %d failed\n",
+		s.fetched, s.upToDate, s.derived, s.noConnectorYAML, len(s.failures))
+	if len(s.failures) == 0 {
+		return nil
+	}
+
+	for _, f := range s.failures {
+		fmt.Printf("  ❌ %s@%s: %v\n", f.repo, f.tag, f.err)
+	}
+	return fmt.Errorf("failed to fetch specifications for %d release(s)", len(s.failures))
+}
 
-				fmt.Printf("  💾 Saved %s\n", connectorYamlPath)
+// processRelease fetches connector.yaml for a single release and writes it,
+// together with the metadata file, to the output folder. Errors returned from
+// this function are specific to the release and should not stop the run.
+func (cmd *CommandSpecifications) processRelease(ctx context.Context, owner, repoName string, release Release) (releaseResult, error) {
+	nameWithOwner := owner + "/" + repoName
+
+	// Create folder path
+	folderPath := filepath.Join(cmd.outputFolder, "github.com", owner, repoName+"@"+release.TagName)
+	if err := os.MkdirAll(folderPath, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create folder %s: %w", folderPath, err)
+	}
+
+	// Check if connector.yaml already exists
+	commitSHA, err := cmd.getCommitForTag(ctx, owner, repoName, release.TagName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get commit for tag %s: %w", release.TagName, err)
+	}
+
+	if !cmd.force && cmd.hasKnownCommitSHA(folderPath, commitSHA) {
+		fmt.Printf("  ✅ Already have latest connector.yaml for %s@%s, skipping\n", nameWithOwner, release.TagName)
+		// Releases fetched before READMEs were saved don't have one yet
+		if _, err := os.Stat(filepath.Join(folderPath, "README.md")); errors.Is(err, os.ErrNotExist) {
+			if err := cmd.saveReadme(ctx, owner, repoName, commitSHA, folderPath); err != nil {
+				fmt.Printf("  ⚠️  Warning: could not fetch README for %s@%s: %v\n", nameWithOwner, release.TagName, err)
 			}
+		}
+		return releaseUpToDate, nil
+	}
 
-			// Write .metadata.yaml with current commit
-			metadataContent, err := yaml.Marshal(Metadata{
-				CommitSHA: commitSHA,
-				FetchedAt: time.Now(),
-			})
+	// Fetch and write connector.yaml
+	fmt.Printf("  📥 Fetching connector.yaml for tag %s...\n", release.TagName)
+	result := releaseFetched
+	var derivedFrom string
+	yamlContent, err := cmd.fetchBlob(ctx, owner, repoName, commitSHA, cmd.config.connectorYAMLPath(nameWithOwner))
+	if errors.Is(err, errNoConnectorYAML) {
+		fmt.Printf("  ⚠️  Warning: no connector.yaml found for %s@%s\n", nameWithOwner, release.TagName)
+		// Still write the metadata file
+		result = releaseNoConnectorYAML
+
+		if cmd.binaryFallback {
+			// A missing asset or a crashing binary doesn't fail the release,
+			// community binaries are outside our control.
+			yamlContent, derivedFrom, err = cmd.deriveSpecification(ctx, repoName, release)
 			if err != nil {
-				return fmt.Errorf("failed to marshal metadata for %s@%s: %w",
-					repo.NameWithOwner, release.TagName, err)
-			}
-			metadataPath := filepath.Join(folderPath, ".metadata.yaml")
-			if err := os.WriteFile(metadataPath, metadataContent, 0644); err != nil {
-				return fmt.Errorf("failed to write .metadata.yaml for %s@%s: %w\n",
-					repo.NameWithOwner, release.TagName, err)
+				fmt.Printf("  ⚠️  Warning: can't derive connector.yaml for %s@%s: %v\n", nameWithOwner, release.TagName, err)
+				yamlContent, derivedFrom = nil, ""
+			} else {
+				result = releaseDerived
 			}
-			fmt.Printf("  💾 Saved %s\n", metadataPath)
 		}
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to fetch connector.yaml: %w", err)
+	}
+
+	// Write connector.yaml
+	if yamlContent != nil {
+		connectorYamlPath := filepath.Join(folderPath, "connector.yaml")
+		if err := os.WriteFile(connectorYamlPath, rewriteDomain(yamlContent), 0644); err != nil {
+			return 0, fmt.Errorf("failed to write connector.yaml: %w", err)
+		}
+
+		fmt.Printf("  💾 Saved %s\n", connectorYamlPath)
 	}
 
+	// Write README.md, the connector pages embed the README of the latest
+	// release
+	if err := cmd.saveReadme(ctx, owner, repoName, commitSHA, folderPath); err != nil {
+		fmt.Printf("  ⚠️  Warning: could not fetch README for %s@%s: %v\n", nameWithOwner, release.TagName, err)
+	}
+
+	// Write .metadata.yaml with current commit
+	metadataContent, err := yaml.Marshal(Metadata{
+		CommitSHA:   commitSHA,
+		FetchedAt:   time.Now(),
+		DerivedFrom: derivedFrom,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	metadataPath := filepath.Join(folderPath, ".metadata.yaml")
+	if err := os.WriteFile(metadataPath, metadataContent, 0644); err != nil {
+		return 0, fmt.Errorf("failed to write .metadata.yaml: %w", err)
+	}
+	fmt.Printf("  💾 Saved %s\n", metadataPath)
+
+	return result, nil
+}
+
+// saveReadme fetches the README of the repository at the given commit and
+// saves it as README.md in folderPath. Nothing is saved if the repository
+// has no README.
+func (cmd *CommandSpecifications) saveReadme(ctx context.Context, owner, repo, commitSHA, folderPath string) error {
+	readme, err := withRetry(ctx, func() (*github.RepositoryContent, *github.Response, error) {
+		return cmd.client.Repositories.GetReadme(ctx, owner, repo,
+			&github.RepositoryContentGetOptions{Ref: commitSHA})
+	})
+	if is404Error(err) {
+		fmt.Printf("  ⚠️  Warning: no README found for %s/%s\n", owner, repo)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get README: %w", err)
+	}
+
+	var content []byte
+	if readme.GetEncoding() == "none" {
+		// Files bigger than 1MB are not included in the response
+		content, err = cmd.fetchRawBlob(ctx, owner, repo, readme.GetSHA())
+	} else {
+		var decoded string
+		decoded, err = readme.GetContent()
+		content = []byte(decoded)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode README: %w", err)
+	}
+
+	readmePath := filepath.Join(folderPath, "README.md")
+	if err := os.WriteFile(readmePath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write README.md: %w", err)
+	}
+	fmt.Printf("  💾 Saved %s\n", readmePath)
 	return nil
 }
 
@@ -177,53 +388,121 @@ func (cmd *CommandSpecifications) getCommitForTag(ctx context.Context, owner, re
 	}
 
 	// Get the reference to the specific tag
-	ref, _, err := cmd.client.Git.GetRef(ctx, owner, repo, refName)
+	ref, err := withRetry(ctx, func() (*github.Reference, *github.Response, error) {
+		return cmd.client.Git.GetRef(ctx, owner, repo, refName)
+	})
 	if err != nil {
-		log.Fatalf("failed to fetch reference for tag %s: %v", tag, err)
+		return "", fmt.Errorf("failed to fetch reference for tag %s: %w", tag, err)
 	}
 
 	// Determine the commit SHA
-	var commitSHA string
-	if ref.Object.GetType() == "tag" { // Annotated tag
+	switch ref.Object.GetType() {
+	case "tag": // Annotated tag
 		// Resolve the object the tag refers to
-		object, _, err := cmd.client.Git.GetTag(ctx, owner, repo, *ref.Object.SHA)
+		object, err := withRetry(ctx, func() (*github.Tag, *github.Response, error) {
+			return cmd.client.Git.GetTag(ctx, owner, repo, ref.Object.GetSHA())
+		})
 		if err != nil {
-			log.Fatalf("failed to fetch annotated tag object for %s: %v", tag, err)
+			return "", fmt.Errorf("failed to fetch annotated tag object for %s: %w", tag, err)
 		}
+		return object.Object.GetSHA(), nil
+	case "commit": // Lightweight tag
+		return ref.Object.GetSHA(), nil
+	default:
+		return "", fmt.Errorf("unexpected object type %s for tag %s", ref.Object.GetType(), tag)
+	}
+}
 
-		commitSHA = *object.Object.SHA
-	} else if ref.Object.GetType() == "commit" { // Lightweight tag
-		commitSHA = *ref.Object.SHA
-	} else {
-		log.Fatalf("unexpected object type %s for tag %s", ref.Object.GetType(), tag)
+// fetchBlob fetches the file at path in the given commit. It uses the contents
+// API and falls back to walking the git trees along the path if that fails.
+// Returns errNoConnectorYAML if the file does not exist.
+func (cmd *CommandSpecifications) fetchBlob(ctx context.Context, owner, repo, commitSHA, path string) ([]byte, error) {
+	blob, err := cmd.fetchContents(ctx, owner, repo, commitSHA, path)
+	if err == nil || errors.Is(err, errNoConnectorYAML) || ctx.Err() != nil {
+		return blob, err
 	}
 
-	return commitSHA, nil
+	fmt.Printf("  ⚠️  Warning: could not fetch %s using the contents API, falling back to git trees: %v\n", path, err)
+	return cmd.fetchBlobFromTree(ctx, owner, repo, commitSHA, path)
 }
 
-func (cmd *CommandSpecifications) fetchBlob(ctx context.Context, owner, repo, commitSHA, path string) ([]byte, error) {
-	// Get the tree for the commit
-	tree, _, err := cmd.client.Git.GetTree(ctx, owner, repo, commitSHA, true)
+// fetchContents fetches the file at path in the given commit using the
+// contents API.
+func (cmd *CommandSpecifications) fetchContents(ctx context.Context, owner, repo, commitSHA, path string) ([]byte, error) {
+	file, err := withRetry(ctx, func() (*github.RepositoryContent, *github.Response, error) {
+		file, _, resp, err := cmd.client.Repositories.GetContents(ctx, owner, repo, path,
+			&github.RepositoryContentGetOptions{Ref: commitSHA})
+		return file, resp, err
+	})
+	if is404Error(err) {
+		return nil, errNoConnectorYAML
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get contents: %w", err)
+	}
+	if file == nil || file.GetType() != "file" {
+		// path points to a directory or a submodule
+		return nil, errNoConnectorYAML
+	}
+
+	if file.GetEncoding() == "none" {
+		// Files bigger than 1MB are not included in the response, fetch the
+		// blob directly.
+		return cmd.fetchRawBlob(ctx, owner, repo, file.GetSHA())
+	}
+
+	content, err := file.GetContent()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tree: %w", err)
+		return nil, fmt.Errorf("failed to decode contents: %w", err)
 	}
+	return []byte(content), nil
+}
 
-	// Find the connector.yaml file
-	var blobTreeEntry *github.TreeEntry
-	for _, entry := range tree.Entries {
-		if entry.GetPath() == path {
-			blobTreeEntry = entry
-			break
+// fetchBlobFromTree finds the file at path by walking the git trees from the
+// root of the commit and fetches its blob. Only the trees along the path are
+// fetched, so big repositories don't produce truncated responses.
+func (cmd *CommandSpecifications) fetchBlobFromTree(ctx context.Context, owner, repo, commitSHA, path string) ([]byte, error) {
+	treeSHA := commitSHA
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		// Get the tree for the commit or directory
+		tree, err := withRetry(ctx, func() (*github.Tree, *github.Response, error) {
+			return cmd.client.Git.GetTree(ctx, owner, repo, treeSHA, false)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tree: %w", err)
 		}
-	}
 
-	if blobTreeEntry == nil {
-		// No connector.yaml
-		return nil, errNoConnectorYAML
+		// Find the next segment of the path
+		var entry *github.TreeEntry
+		for _, e := range tree.Entries {
+			if e.GetPath() == segment {
+				entry = e
+				break
+			}
+		}
+
+		switch {
+		case entry == nil && tree.GetTruncated():
+			// The entry might be in the part of the tree that was not returned
+			return nil, fmt.Errorf("could not find %s: %w", strings.Join(segments[:i+1], "/"), errTruncatedTree)
+		case entry == nil:
+			return nil, errNoConnectorYAML
+		case i == len(segments)-1 && entry.GetType() == "blob":
+			return cmd.fetchRawBlob(ctx, owner, repo, entry.GetSHA())
+		case i < len(segments)-1 && entry.GetType() == "tree":
+			treeSHA = entry.GetSHA()
+		default:
+			return nil, errNoConnectorYAML
+		}
 	}
 
-	// Get the blob content
-	blob, _, err := cmd.client.Git.GetBlobRaw(ctx, owner, repo, blobTreeEntry.GetSHA())
+	return nil, errNoConnectorYAML
+}
+
+func (cmd *CommandSpecifications) fetchRawBlob(ctx context.Context, owner, repo, blobSHA string) ([]byte, error) {
+	blob, err := withRetry(ctx, func() ([]byte, *github.Response, error) {
+		return cmd.client.Git.GetBlobRaw(ctx, owner, repo, blobSHA)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blob: %w", err)
 	}
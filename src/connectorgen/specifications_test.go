@@ -0,0 +1,137 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+)
+
+func TestSpecificationsConfigConnectorYAMLPath(t *testing.T) {
+	cfg := specificationsConfig{
+		Paths: map[string]string{
+			"example/monorepo": "connectors/foo/connector.yaml",
+		},
+	}
+
+	tests := map[string]string{
+		"example/monorepo":               "connectors/foo/connector.yaml",
+		"Example/MonoRepo":               "connectors/foo/connector.yaml",
+		"ConduitIO/conduit-connector-s3": "connector.yaml",
+	}
+	for repo, want := range tests {
+		if got := cfg.connectorYAMLPath(repo); got != want {
+			t.Errorf("connectorYAMLPath(%q) = %q, want %q", repo, got, want)
+		}
+	}
+}
+
+func TestParseSpecificationsConfig(t *testing.T) {
+	// make sure the embedded config is valid
+	if _, err := (&CommandSpecifications{}).parseConfig(); err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+}
+
+func TestProcessReleaseReadme(t *testing.T) {
+	tests := []struct {
+		name string
+		// readme is the existing README.md, it's missing if empty
+		readme string
+		// noRepoReadme makes the repository respond without a README
+		noRepoReadme bool
+		want         string
+		wantRequests int
+	}{{
+		name:         "missing README",
+		want:         "# Connector",
+		wantRequests: 1,
+	}, {
+		name:         "existing README",
+		readme:       "# Old",
+		want:         "# Old",
+		wantRequests: 0,
+	}, {
+		name:         "repository without README",
+		noRepoReadme: true,
+		want:         "",
+		wantRequests: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readmeRequests := 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /repos/owner/repo/git/ref/tags/v0.1.0", func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"ref":"refs/tags/v0.1.0","object":{"type":"commit","sha":"abc"}}`))
+			})
+			mux.HandleFunc("GET /repos/owner/repo/readme", func(w http.ResponseWriter, r *http.Request) {
+				readmeRequests++
+				if tt.noRepoReadme || r.URL.Query().Get("ref") != "abc" {
+					http.NotFound(w, r)
+					return
+				}
+				content := base64.StdEncoding.EncodeToString([]byte("# Connector"))
+				_, _ = w.Write([]byte(`{"encoding":"base64","content":"` + content + `"}`))
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			client := github.NewClient(nil)
+			client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+			outputFolder := t.TempDir()
+			folderPath := filepath.Join(outputFolder, "github.com", "owner", "repo@v0.1.0")
+			if err := os.MkdirAll(folderPath, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(folderPath, ".metadata.yaml"), []byte("commitSHA: abc\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if tt.readme != "" {
+				if err := os.WriteFile(filepath.Join(folderPath, "README.md"), []byte(tt.readme), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd := NewCommandSpecifications(client, "", outputFolder, false, false)
+			result, err := cmd.processRelease(context.Background(), "owner", "repo", Release{TagName: "v0.1.0"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != releaseUpToDate {
+				t.Errorf("expected release to be up to date, got %v", result)
+			}
+			if readmeRequests != tt.wantRequests {
+				t.Errorf("expected %d README requests, got %d", tt.wantRequests, readmeRequests)
+			}
+
+			got, err := os.ReadFile(filepath.Join(folderPath, "README.md"))
+			if err != nil && !os.IsNotExist(err) {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected README %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/conduitio/genfuncs"
+)
+
+//go:embed templates/connector.*.tmpl
+var connectorTemplates embed.FS
+
+// outputTarget is a format in which connector pages can be generated.
+type outputTarget struct {
+	// Extension is the file extension of the generated pages.
+	Extension string
+	// Template is the path of the default template in connectorTemplates.
+	Template string
+	// HTML is true if the template is parsed as an HTML template, which
+	// escapes values automatically.
+	HTML bool
+}
+
+// outputTargets contains the supported output targets by name.
+var outputTargets = map[string]outputTarget{
+	"mdx":      {Extension: ".mdx", Template: "templates/connector.mdx.tmpl"},
+	"markdown": {Extension: ".md", Template: "templates/connector.md.tmpl"},
+	"html":     {Extension: ".html", Template: "templates/connector.html.tmpl", HTML: true},
+}
+
+// pageTarget is an output target selected for generating pages.
+type pageTarget struct {
+	Name string
+	outputTarget
+	// Folder is the folder in which the pages are written.
+	Folder string
+	// TemplatePath is the path of a template overriding the embedded one,
+	// empty if the embedded template is used.
+	TemplatePath string
+}
+
+// executor is implemented by text and HTML templates.
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// parsePageTargets parses the --target and --template flags. Targets are
+// specified as "<name>" or "<name>=<folder>", pages of targets without a
+// folder are written to defaultFolder. Templates are specified as
+// "<name>=<path>", or only as "<path>" if there is a single target.
+func parsePageTargets(targets, templates []string, defaultFolder string) ([]pageTarget, error) {
+	if len(targets) == 0 {
+		targets = []string{"mdx"}
+	}
+
+	var parsed []pageTarget
+	for _, t := range targets {
+		name, folder, _ := strings.Cut(t, "=")
+		target, ok := outputTargets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown target %q, supported targets: %s", name, strings.Join(slices.Sorted(maps.Keys(outputTargets)), ", "))
+		}
+		if slices.ContainsFunc(parsed, func(p pageTarget) bool { return p.Name == name }) {
+			return nil, fmt.Errorf("target %q specified multiple times", name)
+		}
+		if folder == "" {
+			folder = defaultFolder
+		}
+		parsed = append(parsed, pageTarget{Name: name, outputTarget: target, Folder: folder})
+	}
+
+	for _, t := range templates {
+		name, path, ok := strings.Cut(t, "=")
+		if !ok {
+			if len(parsed) > 1 {
+				return nil, fmt.Errorf("template %q needs to be specified as <target>=<path> when generating multiple targets", t)
+			}
+			name, path = parsed[0].Name, t
+		}
+		idx := slices.IndexFunc(parsed, func(p pageTarget) bool { return p.Name == name })
+		if idx == -1 {
+			return nil, fmt.Errorf("template %q specified for target %q, which is not generated", path, name)
+		}
+		parsed[idx].TemplatePath = path
+	}
+
+	return parsed, nil
+}
+
+// parse parses the template of the target, the function map is available in
+// the template.
+func (t pageTarget) parse(funcs template.FuncMap) (executor, error) {
+	var text []byte
+	var err error
+	if t.TemplatePath != "" {
+		text, err = os.ReadFile(t.TemplatePath)
+	} else {
+		text, err = connectorTemplates.ReadFile(t.Template)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s template: %w", t.Name, err)
+	}
+
+	var tmpl executor
+	if t.HTML {
+		tmpl, err = htmltemplate.New(t.Name).Funcs(funcs).Parse(string(text))
+	} else {
+		tmpl, err = template.New(t.Name).Funcs(funcs).Parse(string(text))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", t.Name, err)
+	}
+	return tmpl, nil
+}
+
+// templateFuncs returns the functions available in connector page templates:
+// sprig, the helpers shared with processorgen and the connectorgen specific
+// functions.
+func templateFuncs(now time.Time) template.FuncMap {
+	funcs := template.FuncMap(sprig.FuncMap())
+	maps.Copy(funcs, genfuncs.FuncMap(now))
+	maps.Copy(funcs, funcMap)
+	return funcs
+}
+
+// args creates a map from key value pairs, it is used to pass multiple
+// arguments to a nested template.
+func args(kvs ...any) (map[string]any, error) {
+	if len(kvs)%2 != 0 {
+		return nil, errors.New("args requires even number of arguments")
+	}
+	m := make(map[string]any, len(kvs)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		s, ok := kvs[i].(string)
+		if !ok {
+			return nil, errors.New("even args must be strings")
+		}
+		m[s] = kvs[i+1]
+	}
+	return m, nil
+}
+
+// markdownCell formats a value so it can be used in a markdown table cell.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	value = strings.ReplaceAll(strings.TrimSpace(value), "\n\n", "<br/><br/>")
+	return strings.ReplaceAll(value, "\n", " ")
+}
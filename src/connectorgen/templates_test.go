@@ -0,0 +1,177 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conduitio/genfuncs"
+)
+
+func TestParsePageTargets(t *testing.T) {
+	target := func(name, folder, template string) pageTarget {
+		return pageTarget{Name: name, outputTarget: outputTargets[name], Folder: folder, TemplatePath: template}
+	}
+
+	tests := []struct {
+		name      string
+		targets   []string
+		templates []string
+		want      []pageTarget
+		wantErr   string
+	}{{
+		name: "default",
+		want: []pageTarget{target("mdx", "docs", "")},
+	}, {
+		name:      "single target with template",
+		targets:   []string{"markdown=out/md"},
+		templates: []string{"custom.tmpl"},
+		want:      []pageTarget{target("markdown", "out/md", "custom.tmpl")},
+	}, {
+		name:      "multiple targets",
+		targets:   []string{"mdx", "html=out/html"},
+		templates: []string{"html=page.tmpl"},
+		want:      []pageTarget{target("mdx", "docs", ""), target("html", "out/html", "page.tmpl")},
+	}, {
+		name:    "unknown target",
+		targets: []string{"pdf"},
+		wantErr: `unknown target "pdf", supported targets: html, markdown, mdx`,
+	}, {
+		name:    "duplicate target",
+		targets: []string{"mdx", "mdx=other"},
+		wantErr: `target "mdx" specified multiple times`,
+	}, {
+		name:      "ambiguous template",
+		targets:   []string{"mdx", "html"},
+		templates: []string{"page.tmpl"},
+		wantErr:   "needs to be specified as <target>=<path>",
+	}, {
+		name:      "template for missing target",
+		templates: []string{"html=page.tmpl"},
+		wantErr:   `target "html", which is not generated`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePageTargets(tt.targets, tt.templates, "docs")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPageTargetTemplates(t *testing.T) {
+	spec := Specification{
+		Name:        "demo",
+		Summary:     "Demo <connector>",
+		Description: "Reads {records} | writes them.",
+		Author:      "Acme",
+		Source: &PluginSpecification{Parameters: []Parameter{{
+			Name:        "url",
+			Type:        "string",
+			Description: "URL of the <server> | host.",
+			Validations: []Validation{{Type: "required"}},
+		}}},
+	}
+	d := data{
+		Repository: Repository{
+			NameWithOwner: "acme/conduit-connector-demo",
+			URL:           "https://github.com/acme/conduit-connector-demo",
+			Trust:         TrustCommunity,
+		},
+		SidebarPosition: 1,
+		LatestDownloads: &releaseDownloads{
+			Install: "demo@v0.1.0",
+			Archs:   []string{"amd64"},
+			Rows: []downloadRow{{OS: "linux", Assets: []*Asset{{
+				BrowserDownload: "https://example.com/demo.tar.gz",
+				SHA256:          "8f434346648f6b96df89dda901c5176b10a6d83961dd3c1ac88b59b2dc327aa4",
+			}}}},
+		},
+		Specifications: map[string]Specification{"latest": spec, "v0.1.0": spec},
+		Versions:       []versionSpecification{{Tag: "v0.1.0", Latest: true, Specification: spec}},
+	}
+
+	tests := map[string][]string{
+		"mdx":      {`title: "demo"`, `description: "Demo \u003cconnector\u003e"`, "This connector is maintained by [acme]", "<code>sha256:8f434346648f6b96df89dda901c5176b10a6d83961dd3c1ac88b59b2dc327aa4</code>"},
+		"markdown": {"# demo", "| `url` | string |  | required | URL of the <server> \\| host. |", "not maintained by the Conduit team"},
+		"html":     {"<h1>demo</h1>", "<td class=\"description\">URL of the &lt;server&gt; | host.</td>", "not maintained by the Conduit team"},
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			targets, err := parsePageTargets([]string{name}, nil, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tmpl, err := targets[0].parse(templateFuncs(time.Now()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, d); err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range want {
+				if !strings.Contains(buf.String(), w) {
+					t.Errorf("expected output to contain %q, got:\n%s", w, buf.String())
+				}
+			}
+			if name == "mdx" {
+				if problems := genfuncs.LintMDX(buf.String()); len(problems) > 0 {
+					t.Errorf("generated page contains MDX problems: %v", problems)
+				}
+			}
+		})
+	}
+}
+
+func TestPageTargetTemplateOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.tmpl")
+	if err := os.WriteFile(path, []byte(`{{ slugify .Specifications.latest.Name }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	targets, err := parsePageTargets([]string{"markdown"}, []string{path}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := targets[0].parse(templateFuncs(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data{Specifications: map[string]Specification{"latest": {Name: "Google Drive"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "google-drive"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed trust-config.yaml
+var trustConfigYaml []byte
+
+// TrustLevel describes who maintains a connector.
+type TrustLevel string
+
+const (
+	// TrustOfficial marks connectors maintained by the Conduit team.
+	TrustOfficial TrustLevel = "official"
+	// TrustLabs marks experimental connectors maintained by the Conduit team.
+	TrustLabs TrustLevel = "labs"
+	// TrustCommunity marks all other connectors.
+	TrustCommunity TrustLevel = "community"
+)
+
+// IsConduitTeam returns true if the connector is maintained by the Conduit
+// team.
+func (t TrustLevel) IsConduitTeam() bool {
+	return t == TrustOfficial || t == TrustLabs
+}
+
+// Label returns a human-readable name of the trust level.
+func (t TrustLevel) Label() string {
+	switch t {
+	case TrustOfficial:
+		return "Official"
+	case TrustLabs:
+		return "Conduit Labs"
+	default:
+		return "Community"
+	}
+}
+
+type trustConfig struct {
+	Official []string `yaml:"official"`
+	Labs     []string `yaml:"labs"`
+}
+
+func parseTrustConfig(raw []byte) (trustConfig, error) {
+	var cfg trustConfig
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return trustConfig{}, fmt.Errorf("failed to parse trust-config.yaml: %w", err)
+	}
+
+	// Normalize, so lookups are case-insensitive
+	for i := range cfg.Official {
+		cfg.Official[i] = strings.ToLower(cfg.Official[i])
+	}
+	for i := range cfg.Labs {
+		cfg.Labs[i] = strings.ToLower(cfg.Labs[i])
+	}
+
+	return cfg, nil
+}
+
+// classify returns the trust level of a repository.
+func (cfg trustConfig) classify(repo Repository) TrustLevel {
+	owner, _, _ := strings.Cut(strings.ToLower(repo.NameWithOwner), "/")
+	switch {
+	case slices.Contains(cfg.Official, owner):
+		return TrustOfficial
+	case slices.Contains(cfg.Labs, owner):
+		return TrustLabs
+	default:
+		return TrustCommunity
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+)
+
+func TestParseTrustConfig(t *testing.T) {
+	if _, err := parseTrustConfig(trustConfigYaml); err != nil {
+		t.Fatalf("embedded trust-config.yaml is invalid: %v", err)
+	}
+}
+
+func TestTrustConfigClassify(t *testing.T) {
+	cfg, err := parseTrustConfig([]byte(`
+official: [ConduitIO]
+labs: [conduitio-labs]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		repo Repository
+		want TrustLevel
+	}{
+		{Repository{NameWithOwner: "conduitio/conduit-connector-file"}, TrustOfficial},
+		{Repository{NameWithOwner: "ConduitIO-Labs/conduit-connector-box"}, TrustLabs},
+		{Repository{NameWithOwner: "nickchomey/conduit-connector-surrealdb"}, TrustCommunity},
+		{Repository{NameWithOwner: "conduitio-fork/conduit-connector-file"}, TrustCommunity},
+	}
+	for _, tt := range tests {
+		if got := cfg.classify(tt.repo); got != tt.want {
+			t.Errorf("classify(%s): expected %q, got %q", tt.repo.NameWithOwner, tt.want, got)
+		}
+	}
+}
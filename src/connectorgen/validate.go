@@ -0,0 +1,104 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type CommandValidateSpecifications struct {
+	connectorsFile string
+	specsFolder    string
+}
+
+func NewCommandValidateSpecifications(connectorsFile, specsFolder string) *CommandValidateSpecifications {
+	return &CommandValidateSpecifications{
+		connectorsFile: connectorsFile,
+		specsFolder:    specsFolder,
+	}
+}
+
+func (cmd *CommandValidateSpecifications) Execute(context.Context) error {
+	fmt.Printf("👀 Reading %s ...\n", cmd.connectorsFile)
+
+	// Read and parse the input JSON file
+	data, err := os.ReadFile(cmd.connectorsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	var repositories []Repository
+	if err := json.Unmarshal(data, &repositories); err != nil {
+		return fmt.Errorf("failed to parse JSON input: %w", err)
+	}
+
+	var validated, invalid int
+	for _, repo := range repositories {
+		owner, repoName, found := strings.Cut(repo.NameWithOwner, "/")
+		if !found {
+			fmt.Printf("⚠️  Warning: invalid repository name format %s\n", repo.NameWithOwner)
+			continue
+		}
+
+		for _, release := range repo.Releases {
+			path := filepath.Join(cmd.specsFolder, "github.com", owner, repoName+"@"+release.TagName, "connector.yaml")
+			found, errs := cmd.validateFile(path, release.TagName)
+			if !found {
+				continue
+			}
+
+			validated++
+			if len(errs) == 0 {
+				continue
+			}
+
+			invalid++
+			fmt.Printf("\n❌ %s@%s (%s)\n", repo.NameWithOwner, release.TagName, path)
+			for _, err := range errs {
+				fmt.Printf("  - %v\n", err)
+			}
+		}
+	}
+
+	fmt.Printf("\n📊 Summary: %d specifications validated, %d invalid\n", validated, invalid)
+	if invalid > 0 {
+		return fmt.Errorf("found %d invalid specification(s)", invalid)
+	}
+	return nil
+}
+
+// validateFile validates the connector.yaml at path. It returns false if the
+// file does not exist.
+func (cmd *CommandValidateSpecifications) validateFile(path, tag string) (bool, []error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return true, []error{err}
+	}
+
+	spec, err := decodeConnectorYAML(raw)
+	if err != nil {
+		return true, []error{err}
+	}
+
+	return true, spec.Validate(tag)
+}
@@ -0,0 +1,529 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// pluginPrefixes are prefixes of plugin names selecting the plugin type, they
+// are ignored when resolving plugins.
+var pluginPrefixes = []string{"builtin:", "standalone:"}
+
+// CommandValidatePipeline validates a pipeline configuration file against the
+// downloaded connector specifications and the builtin processor
+// specifications, without connecting to Conduit.
+type CommandValidatePipeline struct {
+	connectorsFile   string
+	specsFolder      string
+	processorsFolder string
+	pipelineFile     string
+}
+
+func NewCommandValidatePipeline(connectorsFile, specsFolder, processorsFolder, pipelineFile string) *CommandValidatePipeline {
+	return &CommandValidatePipeline{
+		connectorsFile:   connectorsFile,
+		specsFolder:      specsFolder,
+		processorsFolder: processorsFolder,
+		pipelineFile:     pipelineFile,
+	}
+}
+
+// pipelineProblem is a problem found in a pipeline configuration file.
+type pipelineProblem struct {
+	Line int
+	// Location describes the pipeline, connector or processor containing the
+	// problem.
+	Location string
+	Message  string
+	// Warning is true if the problem doesn't make the configuration invalid,
+	// e.g. because a plugin can't be checked.
+	Warning bool
+}
+
+func (p pipelineProblem) String() string {
+	return fmt.Sprintf("%d: %s: %s", p.Line, p.Location, p.Message)
+}
+
+// processorSpecification is the specification of a builtin processor, as
+// generated by Conduit for processorgen.
+type processorSpecification struct {
+	Name       string
+	Version    string
+	Parameters []Parameter
+}
+
+func (cmd *CommandValidatePipeline) Execute(context.Context) error {
+	fmt.Printf("👀 Reading %s ...\n", cmd.connectorsFile)
+
+	data, err := os.ReadFile(cmd.connectorsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	var repositories []Repository
+	if err := json.Unmarshal(data, &repositories); err != nil {
+		return fmt.Errorf("failed to parse JSON input: %w", err)
+	}
+
+	fmt.Printf("👀 Reading processor specifications from %s ...\n", cmd.processorsFolder)
+	processors, err := readProcessorSpecifications(cmd.processorsFolder)
+	if err != nil {
+		return err
+	}
+	trustCfg, err := parseTrustConfig(trustConfigYaml)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("👀 Reading %s ...\n", cmd.pipelineFile)
+	raw, err := os.ReadFile(cmd.pipelineFile)
+	if err != nil {
+		return fmt.Errorf("failed to read pipeline configuration: %w", err)
+	}
+	cfg, err := parsePipelineConfig(raw)
+	if err != nil {
+		return err
+	}
+
+	v := &pipelineValidator{
+		repositories: repositories,
+		trust:        trustCfg,
+		docs:         NewCommandDocs(cmd.connectorsFile, cmd.specsFolder, "", nil),
+		processors:   processors,
+	}
+	problems := v.validate(cfg)
+
+	errCount := 0
+	for _, p := range problems {
+		if p.Warning {
+			fmt.Printf("⚠️  %s:%v\n", cmd.pipelineFile, p)
+			continue
+		}
+		errCount++
+		fmt.Printf("❌ %s:%v\n", cmd.pipelineFile, p)
+	}
+	if errCount > 0 {
+		return fmt.Errorf("found %d problem(s) in %s", errCount, cmd.pipelineFile)
+	}
+
+	fmt.Printf("✅ %s is valid\n", cmd.pipelineFile)
+	return nil
+}
+
+// readProcessorSpecifications reads the builtin processor specifications
+// (processorgen/specs) by processor name.
+func readProcessorSpecifications(folder string) (map[string]processorSpecification, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read processor specifications: %w", err)
+	}
+
+	processors := map[string]processorSpecification{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(folder, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		var proc struct {
+			Specification struct {
+				Name       string `json:"name"`
+				Version    string `json:"version"`
+				Parameters map[string]struct {
+					Default     string       `json:"default"`
+					Description string       `json:"description"`
+					Type        string       `json:"type"`
+					Validations []Validation `json:"validations"`
+				} `json:"parameters"`
+			} `json:"specification"`
+		}
+		if err := json.Unmarshal(raw, &proc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", entry.Name(), err)
+		}
+
+		spec := processorSpecification{Name: proc.Specification.Name, Version: proc.Specification.Version}
+		for _, name := range slices.Sorted(maps.Keys(proc.Specification.Parameters)) {
+			p := proc.Specification.Parameters[name]
+			spec.Parameters = append(spec.Parameters, Parameter{
+				Name:        name,
+				Description: p.Description,
+				Type:        p.Type,
+				Default:     p.Default,
+				Validations: p.Validations,
+			})
+		}
+		processors[spec.Name] = spec
+	}
+	return processors, nil
+}
+
+type pipelineValidator struct {
+	repositories []Repository
+	trust        trustConfig
+	docs         *CommandDocs
+	processors   map[string]processorSpecification
+
+	problems []pipelineProblem
+}
+
+func (v *pipelineValidator) validate(cfg pipelineConfig) []pipelineProblem {
+	v.problems = nil
+
+	if !slices.Contains(supportedPipelineVersions, string(cfg.Version)) {
+		v.errorf(1, "configuration", "unsupported version %q, expected one of %s", cfg.Version, strings.Join(supportedPipelineVersions, ", "))
+	}
+	if len(cfg.Pipelines) == 0 {
+		v.errorf(1, "configuration", "no pipelines defined")
+	}
+
+	pipelineIDs := map[string]bool{}
+	for i, p := range cfg.Pipelines {
+		location := fmt.Sprintf("pipeline %q", p.ID)
+		if p.ID == "" {
+			location = fmt.Sprintf("pipelines[%d]", i)
+			v.errorf(p.Line, location, "id is required")
+		} else if pipelineIDs[p.ID] {
+			v.errorf(p.Line, location, "duplicate pipeline id")
+		}
+		pipelineIDs[p.ID] = true
+		v.checkDuplicateIDs(location, p)
+		if p.Status != "" && p.Status != "running" && p.Status != "stopped" {
+			v.errorf(p.Line, location, "invalid status %q, expected running or stopped", p.Status)
+		}
+
+		for j, c := range p.Connectors {
+			v.validateConnector(location, j, c)
+		}
+		for j, proc := range p.Processors {
+			v.validateProcessor(location, j, proc)
+		}
+		if p.DLQ != nil && p.DLQ.Plugin != "" {
+			dlqLocation := location + ", dead-letter-queue"
+			if p.DLQ.WindowSize != nil && *p.DLQ.WindowSize < 0 {
+				v.errorf(p.DLQ.Line, dlqLocation, "window-size must not be negative")
+			}
+			if p.DLQ.WindowNackThreshold != nil && *p.DLQ.WindowNackThreshold < 0 {
+				v.errorf(p.DLQ.Line, dlqLocation, "window-nack-threshold must not be negative")
+			}
+			v.validateConnectorSettings(dlqLocation, p.DLQ.Line, p.DLQ.Plugin, "destination", p.DLQ.Settings)
+		}
+	}
+
+	slices.SortStableFunc(v.problems, func(a, b pipelineProblem) int { return a.Line - b.Line })
+	return v.problems
+}
+
+// checkDuplicateIDs reports connectors and processors of a pipeline that
+// share an id.
+func (v *pipelineValidator) checkDuplicateIDs(location string, p pipelineConfigPipeline) {
+	seen := map[string]bool{}
+	check := func(id, kind string, line int) {
+		if id == "" {
+			return
+		}
+		if seen[kind+id] {
+			v.errorf(line, location, "duplicate %s id %q", kind, id)
+		}
+		seen[kind+id] = true
+	}
+	for _, c := range p.Connectors {
+		check(c.ID, "connector", c.Line)
+		for _, proc := range c.Processors {
+			check(proc.ID, "processor", proc.Line)
+		}
+	}
+	for _, proc := range p.Processors {
+		check(proc.ID, "processor", proc.Line)
+	}
+}
+
+func (v *pipelineValidator) validateConnector(pipelineLocation string, index int, c pipelineConfigConnector) {
+	location := fmt.Sprintf("%s, connector %q", pipelineLocation, c.ID)
+	if c.ID == "" {
+		location = fmt.Sprintf("%s, connectors[%d]", pipelineLocation, index)
+		v.errorf(c.Line, location, "id is required")
+	}
+	if c.Type != "source" && c.Type != "destination" {
+		v.errorf(c.Line, location, "invalid type %q, expected source or destination", c.Type)
+	}
+	if c.Plugin == "" {
+		v.errorf(c.Line, location, "plugin is required")
+	} else if c.Type == "source" || c.Type == "destination" {
+		v.validateConnectorSettings(location, c.Line, c.Plugin, c.Type, c.Settings)
+	}
+
+	for j, proc := range c.Processors {
+		v.validateProcessor(location, j, proc)
+	}
+}
+
+func (v *pipelineValidator) validateConnectorSettings(location string, line int, plugin, pluginType string, settings pipelineSettings) {
+	name, version := splitPluginName(plugin)
+	repo, err := v.resolveConnector(name)
+	if err != nil {
+		v.errorf(line, location, "%v", err)
+		return
+	}
+
+	latest := ""
+	if idx := slices.IndexFunc(repo.Releases, func(r Release) bool { return r.IsLatest }); idx != -1 {
+		latest = repo.Releases[idx].TagName
+	}
+
+	tag := version
+	switch {
+	case tag == "" || tag == "latest":
+		if latest == "" {
+			v.warnf(line, location, "%s has no release, settings are not checked", repo.NameWithOwner)
+			return
+		}
+		tag = latest
+	case !slices.ContainsFunc(repo.Releases, func(r Release) bool { return r.TagName == tag }):
+		msg := fmt.Sprintf("unknown version %q of %s", tag, repo.NameWithOwner)
+		if latest != "" {
+			msg += ", the latest version is " + latest
+		}
+		v.errorf(line, location, "%s", msg)
+		return
+	}
+
+	spec, err := v.docs.loadSpecification(repo, tag)
+	if err != nil {
+		v.warnf(line, location, "no specification available for %s@%s, settings are not checked", repo.NameWithOwner, tag)
+		return
+	}
+
+	plugSpec := spec.Source
+	if pluginType == "destination" {
+		plugSpec = spec.Destination
+	}
+	if plugSpec == nil {
+		v.errorf(line, location, "%s@%s does not implement a %s", repo.NameWithOwner, tag, pluginType)
+		return
+	}
+	v.validateSettings(location, line, plugSpec.Parameters, settings)
+}
+
+func (v *pipelineValidator) validateProcessor(parentLocation string, index int, p pipelineConfigProcessor) {
+	location := fmt.Sprintf("%s, processor %q", parentLocation, p.ID)
+	if p.ID == "" {
+		location = fmt.Sprintf("%s, processors[%d]", parentLocation, index)
+		v.errorf(p.Line, location, "id is required")
+	}
+	if p.Workers < 0 {
+		v.errorf(p.Line, location, "workers must not be negative")
+	}
+	if p.Plugin == "" {
+		v.errorf(p.Line, location, "plugin is required")
+		return
+	}
+	if strings.HasPrefix(p.Plugin, "standalone:") {
+		v.warnf(p.Line, location, "standalone processor %q is not checked", p.Plugin)
+		return
+	}
+
+	name, version := splitPluginName(p.Plugin)
+	spec, ok := v.processors[name]
+	if !ok {
+		v.errorf(p.Line, location, "unknown processor plugin %q%s", p.Plugin, didYouMean(name, slices.Collect(maps.Keys(v.processors))))
+		return
+	}
+	if version != "" && version != "latest" && strings.TrimPrefix(version, "v") != strings.TrimPrefix(spec.Version, "v") {
+		v.warnf(p.Line, location, "processor %s is documented for version %s, settings are checked against it", name, spec.Version)
+	}
+	v.validateSettings(location, p.Line, spec.Parameters, p.Settings)
+}
+
+// validateSettings reports unknown settings, values that don't match the
+// parameter type or validations and missing required parameters.
+func (v *pipelineValidator) validateSettings(location string, line int, params []Parameter, settings pipelineSettings) {
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.Name
+	}
+
+	set := map[string]bool{}
+	for _, s := range settings {
+		set[s.Key] = true
+		idx := slices.IndexFunc(params, func(p Parameter) bool { return matchParameterKey(s.Key, p.Name) })
+		if idx == -1 {
+			v.errorf(s.Line, location, "unknown setting %q%s", s.Key, didYouMean(s.Key, names))
+			continue
+		}
+		for _, msg := range checkSettingValue(params[idx], s.Value) {
+			v.errorf(s.Line, location, "setting %q: %s", s.Key, msg)
+		}
+	}
+
+	for _, p := range params {
+		if p.IsRequired() && p.Default == "" && !strings.Contains(p.Name, "*") && !set[p.Name] {
+			v.errorf(line, location, "missing required setting %q", p.Name)
+		}
+	}
+}
+
+// checkSettingValue checks the value against the type and validations of the
+// parameter. Values referencing environment variables are resolved by Conduit
+// at runtime and can't be checked.
+func checkSettingValue(p Parameter, value string) []string {
+	if strings.Contains(value, "${") {
+		return nil
+	}
+
+	var problems []string
+	// empty values are valid for all types, like in Conduit
+	if t, err := parameterTypeToConfig(p.Type); err == nil && value != "" {
+		if err := validateValue(t, value); err != nil {
+			return []string{err.Error()}
+		}
+	}
+	for _, val := range p.Validations {
+		cv, err := val.toConfig()
+		if err != nil {
+			continue
+		}
+		if err := cv.Validate(value); err != nil {
+			problems = append(problems, fmt.Sprintf("value %q is invalid: %s", value, val.Describe()))
+		}
+	}
+	return problems
+}
+
+func (v *pipelineValidator) errorf(line int, location, format string, args ...any) {
+	v.problems = append(v.problems, pipelineProblem{Line: line, Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *pipelineValidator) warnf(line int, location, format string, args ...any) {
+	v.problems = append(v.problems, pipelineProblem{Line: line, Location: location, Message: fmt.Sprintf(format, args...), Warning: true})
+}
+
+// resolveConnector returns the repository of a connector plugin. Plugins can
+// be referenced by connector name (e.g. "postgres"), repository name or
+// module path (e.g. "github.com/ConduitIO/conduit-connector-postgres"). If
+// multiple repositories provide a connector with the same name, connectors
+// maintained by the Conduit team are preferred.
+func (v *pipelineValidator) resolveConnector(name string) (Repository, error) {
+	name = strings.ToLower(strings.TrimPrefix(name, "github.com/"))
+
+	var matches []Repository
+	var names []string
+	for _, repo := range v.repositories {
+		nameWithOwner := strings.ToLower(repo.NameWithOwner)
+		_, repoName, _ := strings.Cut(nameWithOwner, "/")
+		shortName := strings.TrimPrefix(repoName, "conduit-connector-")
+		if name == nameWithOwner || name == repoName || name == shortName {
+			matches = append(matches, repo)
+		}
+		names = append(names, shortName)
+	}
+
+	switch len(matches) {
+	case 0:
+		return Repository{}, fmt.Errorf("unknown connector plugin %q%s", name, didYouMean(name, names))
+	case 1:
+		return matches[0], nil
+	}
+
+	team := slices.DeleteFunc(slices.Clone(matches), func(r Repository) bool { return !v.trust.classify(r).IsConduitTeam() })
+	if len(team) == 1 {
+		return team[0], nil
+	}
+	candidates := make([]string, len(matches))
+	for i, r := range matches {
+		candidates[i] = "github.com/" + r.NameWithOwner
+	}
+	return Repository{}, fmt.Errorf("connector plugin %q is ambiguous, use one of: %s", name, strings.Join(candidates, ", "))
+}
+
+// splitPluginName removes the plugin type prefix and splits the version
+// suffix from a plugin name, e.g. "builtin:postgres@v0.14.0".
+func splitPluginName(plugin string) (name, version string) {
+	for _, prefix := range pluginPrefixes {
+		plugin = strings.TrimPrefix(plugin, prefix)
+	}
+	name, version, _ = strings.Cut(plugin, "@")
+	return name, version
+}
+
+// matchParameterKey returns true if the setting key matches the parameter
+// name, a wildcard in the name matches a single segment of the key.
+func matchParameterKey(key, name string) bool {
+	if !strings.Contains(name, "*") {
+		return key == name
+	}
+	keyTokens := strings.Split(key, ".")
+	nameTokens := strings.Split(name, ".")
+	if len(keyTokens) != len(nameTokens) {
+		return false
+	}
+	for i, t := range nameTokens {
+		if t != "*" && t != keyTokens[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// didYouMean returns a suggestion for the closest candidate, or an empty
+// string if no candidate is close enough.
+func didYouMean(s string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, c := range slices.Sorted(slices.Values(candidates)) {
+		if strings.Contains(c, "*") {
+			continue
+		}
+		d := levenshtein(strings.ToLower(s), strings.ToLower(c))
+		if bestDistance == -1 || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	if bestDistance == -1 || bestDistance > max(2, len(s)/3) {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur := make([]int, len(br)+1)
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(br)]
+}
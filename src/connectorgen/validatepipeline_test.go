@@ -0,0 +1,245 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const demoConnectorYAML = `version: "1.0"
+specification:
+  name: demo
+  version: %s
+  source:
+    parameters:
+      - name: url
+        type: string
+        validations:
+          - type: required
+      - name: mode
+        type: string
+        default: snapshot
+        validations:
+          - type: inclusion
+            value: snapshot,cdc
+      - name: batchSize
+        type: int
+        default: "10"
+        validations:
+          - type: greater-than
+            value: "0"
+      - name: collections.*.format
+        type: string
+`
+
+func newTestPipelineValidator(t *testing.T) *pipelineValidator {
+	specsFolder := t.TempDir()
+	for _, tag := range []string{"v0.1.0", "v0.2.0"} {
+		dir := filepath.Join(specsFolder, "github.com", "ConduitIO", "conduit-connector-demo@"+tag)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		spec := strings.Replace(demoConnectorYAML, "%s", tag, 1)
+		if tag == "v0.1.0" {
+			// the old version doesn't know the mode parameter
+			spec = strings.Replace(spec, "name: mode", "name: legacyMode", 1)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "connector.yaml"), []byte(spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	trustCfg, err := parseTrustConfig(trustConfigYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &pipelineValidator{
+		repositories: []Repository{{
+			NameWithOwner: "ConduitIO/conduit-connector-demo",
+			Releases:      []Release{{TagName: "v0.2.0", IsLatest: true}, {TagName: "v0.1.0"}},
+		}, {
+			NameWithOwner: "acme/conduit-connector-demo",
+		}},
+		trust: trustCfg,
+		docs:  NewCommandDocs("", specsFolder, "", nil),
+		processors: map[string]processorSpecification{
+			"field.set": {Name: "field.set", Version: "v0.1.0", Parameters: []Parameter{
+				{Name: "field", Type: "string", Validations: []Validation{{Type: "required"}, {Type: "exclusion", Value: ".Position"}}},
+				{Name: "value", Type: "string", Validations: []Validation{{Type: "required"}}},
+			}},
+		},
+	}
+}
+
+func TestValidatePipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		pipeline string
+		want     []string
+	}{{
+		name: "valid",
+		pipeline: `
+version: 2.2
+pipelines:
+  - id: p1
+    connectors:
+      - id: src
+        type: source
+        plugin: builtin:demo
+        settings:
+          url: http://localhost
+          batchSize: 5
+          collections.users.format: raw
+        processors:
+          - id: set
+            plugin: field.set
+            settings:
+              field: .Key
+              value: ${VALUE}
+`,
+	}, {
+		name: "older version",
+		pipeline: `
+version: 2.0
+pipelines:
+  - id: p1
+    connectors:
+      - id: src
+        type: source
+        plugin: demo
+        settings:
+          url: http://localhost
+`,
+	}, {
+		name: "unknown version",
+		pipeline: `
+version: 2.9
+pipelines:
+  - id: p1
+    connectors:
+      - id: src
+        type: source
+        plugin: demo
+        settings:
+          url: http://localhost
+`,
+		want: []string{
+			`1: configuration: unsupported version "2.9", expected one of 2.0, 2.1, 2.2`,
+		},
+	}, {
+		name: "settings problems",
+		pipeline: `
+version: 2.2
+pipelines:
+  - id: p1
+    connectors:
+      - id: src
+        type: source
+        plugin: demo
+        settings:
+          ulr: http://localhost
+          mode: stream
+          batchSize: ten
+`,
+		want: []string{
+			`5: pipeline "p1", connector "src": missing required setting "url"`,
+			`9: pipeline "p1", connector "src": unknown setting "ulr", did you mean "url"?`,
+			`10: pipeline "p1", connector "src": setting "mode": value "stream" is invalid: one of: snapshot, cdc`,
+			`11: pipeline "p1", connector "src": setting "batchSize": "ten" is not a valid int`,
+		},
+	}, {
+		name: "version suffix",
+		pipeline: `
+version: 2.2
+pipelines:
+  - id: p1
+    connectors:
+      - id: src
+        type: source
+        plugin: demo@v0.1.0
+        settings:
+          url: http://localhost
+          mode: cdc
+      - id: dst
+        type: destination
+        plugin: github.com/ConduitIO/conduit-connector-demo@v0.3.0
+`,
+		want: []string{
+			`10: pipeline "p1", connector "src": unknown setting "mode"`,
+			`11: pipeline "p1", connector "dst": unknown version "v0.3.0" of ConduitIO/conduit-connector-demo, the latest version is v0.2.0`,
+		},
+	}, {
+		name: "plugins and structure",
+		pipeline: `
+version: 1.0
+pipelines:
+  - id: p1
+    connectors:
+      - id: src
+        type: source
+        plugin: dmeo
+      - id: src
+        type: destination
+        plugin: demo
+    processors:
+      - id: proc
+        plugin: field.st
+`,
+		want: []string{
+			`1: configuration: unsupported version "1.0", expected one of 2.0, 2.1, 2.2`,
+			`5: pipeline "p1", connector "src": unknown connector plugin "dmeo", did you mean "demo"?`,
+			`8: pipeline "p1": duplicate connector id "src"`,
+			`8: pipeline "p1", connector "src": ConduitIO/conduit-connector-demo@v0.2.0 does not implement a destination`,
+			`12: pipeline "p1", processor "proc": unknown processor plugin "field.st", did you mean "field.set"?`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parsePipelineConfig([]byte(strings.TrimPrefix(tt.pipeline, "\n")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, p := range newTestPipelineValidator(t).validate(cfg) {
+				if !p.Warning {
+					got = append(got, p.String())
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("expected problems:\n%s\ngot:\n%s", strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	candidates := []string{"url", "tables", "cdcMode", "collections.*.format"}
+	tests := map[string]string{
+		"ulr":    `, did you mean "url"?`,
+		"Tables": `, did you mean "tables"?`,
+		"cdcmod": `, did you mean "cdcMode"?`,
+		"schema": "",
+	}
+	for in, want := range tests {
+		if got := didYouMean(in, candidates); got != want {
+			t.Errorf("didYouMean(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
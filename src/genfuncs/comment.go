@@ -0,0 +1,74 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genfuncs
+
+import "strings"
+
+// ExampleCommentWidth is the maximum length of comment lines in example
+// pipelines, including the indentation of the settings.
+const ExampleCommentWidth = 80
+
+// ExampleComment formats the description and type of a parameter as a
+// comment in an example pipeline. Markdown line breaks are removed and lines
+// are wrapped, so the comment fits into ExampleCommentWidth. Parameters
+// without a description only get their type.
+func ExampleComment(description, paramType string) string {
+	const (
+		indent     = len("          # ")
+		tmpNewLine = "〠"
+	)
+
+	if strings.TrimSpace(description) == "" {
+		return "Type: " + paramType
+	}
+
+	// remove markdown new lines
+	description = strings.ReplaceAll(description, "\n\n", tmpNewLine)
+	description = strings.ReplaceAll(description, "\n", " ")
+	description = strings.ReplaceAll(description, tmpNewLine, "\n")
+
+	comment := FormatMultiline(description, "", ExampleCommentWidth-indent)
+	return comment + "Type: " + paramType
+}
+
+// FormatMultiline prefixes each line of the input and wraps lines longer than
+// maxLineLen without breaking words.
+func FormatMultiline(input, prefix string, maxLineLen int) string {
+	textLen := maxLineLen - len(prefix)
+
+	var sb strings.Builder
+	for _, line := range strings.Split(input, "\n") {
+		if len(line) <= textLen {
+			sb.WriteString(prefix + line + "\n")
+			continue
+		}
+
+		var current string
+		for _, word := range strings.Fields(line) {
+			if current != "" && len(current)+len(word) >= textLen {
+				sb.WriteString(prefix + current + "\n")
+				current = ""
+			}
+			if current != "" {
+				current += " "
+			}
+			current += word
+		}
+		if current != "" {
+			sb.WriteString(prefix + current + "\n")
+		}
+	}
+	return sb.String()
+}
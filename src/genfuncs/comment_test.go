@@ -0,0 +1,97 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genfuncs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatMultiline(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		prefix string
+		width  int
+		want   string
+	}{{
+		name:  "short line",
+		input: "short line",
+		width: 20,
+		want:  "short line\n",
+	}, {
+		name:  "wrapped line",
+		input: "the quick brown fox jumps over the lazy dog",
+		width: 16,
+		want:  "the quick brown\nfox jumps over\nthe lazy dog\n",
+	}, {
+		name:   "prefix",
+		input:  "first\nthe quick brown fox",
+		prefix: "# ",
+		width:  12,
+		want:   "# first\n# the quick\n# brown fox\n",
+	}, {
+		name:  "long word",
+		input: "a verylongwordthatdoesnotfit b",
+		width: 10,
+		want:  "a\nverylongwordthatdoesnotfit\nb\n",
+	}, {
+		name:  "empty",
+		input: "",
+		width: 10,
+		want:  "\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatMultiline(tt.input, tt.prefix, tt.width); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExampleComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        string
+	}{{
+		name:        "empty description",
+		description: "",
+		want:        "Type: string",
+	}, {
+		name:        "blank description",
+		description: " \n",
+		want:        "Type: string",
+	}, {
+		name:        "markdown line breaks",
+		description: "First line\ncontinued.\n\nSecond paragraph.",
+		want:        "First line continued.\nSecond paragraph.\nType: string",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExampleComment(tt.description, "string"); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	long := strings.Repeat("word ", 40)
+	for _, line := range strings.Split(ExampleComment(long, "string"), "\n") {
+		if len("          # "+line) > ExampleCommentWidth {
+			t.Errorf("comment line exceeds %d characters: %q", ExampleCommentWidth, line)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package genfuncs contains helper functions shared by the generators of the
+// documentation (connectorgen and processorgen). The functions are available
+// in templates through FuncMap.
+package genfuncs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// FuncMap returns the helper functions for use in templates. Relative dates
+// are calculated based on now, which should be taken from the input (e.g. the
+// time it was fetched), so the output doesn't change between runs. If now is
+// the zero time, relativeDate fails.
+func FuncMap(now time.Time) template.FuncMap {
+	return template.FuncMap{
+		"slugify":         Slugify,
+		"compareVersions": CompareVersions,
+		"humanBytes":      HumanBytes,
+		"relativeDate": func(t time.Time) (string, error) {
+			if now.IsZero() {
+				return "", errors.New("relativeDate: no reference time configured")
+			}
+			return RelativeDate(t, now), nil
+		},
+	}
+}
+
+// Slugify converts a name into a slug containing only lowercase letters,
+// digits and dashes.
+func Slugify(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return sb.String()
+}
+
+// HumanBytes formats a size in bytes using binary units, e.g. "6.2 MiB".
+func HumanBytes(size int) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := unit, 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// RelativeDate describes how long ago t was compared to now, e.g. "3 days
+// ago" or "in 2 weeks".
+func RelativeDate(t, now time.Time) string {
+	d := now.Sub(t)
+	format := "%s ago"
+	if d < 0 {
+		d = -d
+		format = "in %s"
+	}
+
+	days := int(d.Hours() / 24)
+	var amount string
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1 && format == "%s ago":
+		return "yesterday"
+	case days == 1:
+		return "tomorrow"
+	case days < 14:
+		amount = plural(days, "day")
+	case days < 60:
+		amount = plural(days/7, "week")
+	case days < 365:
+		amount = plural(days/30, "month")
+	default:
+		amount = plural(days/365, "year")
+	}
+	return fmt.Sprintf(format, amount)
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
+
+// version is a parsed semantic version.
+type version struct {
+	major, minor, patch int
+	prerelease          []string
+}
+
+// parseVersion parses a semantic version with an optional "v" prefix. Minor
+// and patch versions can be omitted, build metadata is ignored.
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, pre, hasPre := strings.Cut(s, "-")
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return version{}, false
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (len(p) > 1 && p[0] == '0') {
+			return version{}, false
+		}
+		nums[i] = n
+	}
+
+	v := version{major: nums[0], minor: nums[1], patch: nums[2]}
+	if hasPre {
+		if pre == "" {
+			return version{}, false
+		}
+		v.prerelease = strings.Split(pre, ".")
+	}
+	return v, true
+}
+
+// CompareVersions compares two semantic versions and returns -1 if a is lower
+// than b, 1 if a is greater than b and 0 if they are equal. Strings that are
+// not valid semantic versions are lower than any valid version and are
+// compared lexically among themselves.
+func CompareVersions(a, b string) int {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	switch {
+	case !okA && !okB:
+		return strings.Compare(a, b)
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+
+	for _, c := range [][2]int{{va.major, vb.major}, {va.minor, vb.minor}, {va.patch, vb.patch}} {
+		if c[0] != c[1] {
+			return sign(c[0] - c[1])
+		}
+	}
+	return comparePrerelease(va.prerelease, vb.prerelease)
+}
+
+// comparePrerelease compares pre-release identifiers according to the
+// semantic versioning specification. A version without pre-release is
+// greater than one with a pre-release.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		na, errA := strconv.Atoi(a[i])
+		nb, errB := strconv.Atoi(b[i])
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				return sign(na - nb)
+			}
+		case errA == nil:
+			// numeric identifiers have lower precedence
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
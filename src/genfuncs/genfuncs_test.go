@@ -0,0 +1,151 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genfuncs
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"file":             "file",
+		"Google Drive":     "google-drive",
+		"activemq-artemis": "activemq-artemis",
+		"  SQL_Server 2 ":  "sql-server-2",
+		"redis/streams":    "redis-streams",
+	}
+	for in, want := range tests {
+		if got := Slugify(in); got != want {
+			t.Errorf("Slugify(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestHumanBytes(t *testing.T) {
+	tests := map[int]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1024:            "1.0 KiB",
+		6519231:         "6.2 MiB",
+		3 << 30:         "3.0 GiB",
+		5<<40 + 512<<30: "5.5 TiB",
+	}
+	for size, want := range tests {
+		if got := HumanBytes(size); got != want {
+			t.Errorf("HumanBytes(%d): expected %q, got %q", size, want, got)
+		}
+	}
+}
+
+func TestRelativeDate(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	tests := map[time.Duration]string{
+		0:              "today",
+		-3 * time.Hour: "today",
+		-day:           "yesterday",
+		day:            "tomorrow",
+		-5 * day:       "5 days ago",
+		-20 * day:      "2 weeks ago",
+		-45 * day:      "6 weeks ago",
+		-70 * day:      "2 months ago",
+		-400 * day:     "1 year ago",
+		-800 * day:     "2 years ago",
+		10 * day:       "in 10 days",
+	}
+	for offset, want := range tests {
+		if got := RelativeDate(now.Add(offset), now); got != want {
+			t.Errorf("RelativeDate(now%+v): expected %q, got %q", offset, want, got)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	// sorted from lowest to highest
+	sorted := []string{
+		"invalid",
+		"latest",
+		"v0.1.0",
+		"0.2",
+		"v1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-alpha.beta",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+		"v1.2.0",
+		"v1.10.0",
+		"v2.0.0",
+	}
+	for i, a := range sorted {
+		for j, b := range sorted {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := CompareVersions(a, b); got != want {
+				t.Errorf("CompareVersions(%q, %q): expected %d, got %d", a, b, want, got)
+			}
+		}
+	}
+
+	// build metadata is ignored
+	if got := CompareVersions("v1.0.0+build.1", "v1.0.0"); got != 0 {
+		t.Errorf("expected build metadata to be ignored, got %d", got)
+	}
+
+	shuffled := []string{"v1.10.0", "invalid", "v1.0.0-rc.1", "v0.1.0", "v1.2.0"}
+	slices.SortFunc(shuffled, CompareVersions)
+	want := []string{"invalid", "v0.1.0", "v1.0.0-rc.1", "v1.2.0", "v1.10.0"}
+	if !slices.Equal(shuffled, want) {
+		t.Errorf("expected %v, got %v", want, shuffled)
+	}
+}
+
+func TestFuncMap(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	tmpl := template.Must(template.New("").Funcs(FuncMap(now)).Parse(
+		`{{ slugify .Name }} {{ compareVersions "v1.0.0" "v1.1.0" }} {{ humanBytes .Size }} {{ relativeDate .Published }}`,
+	))
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]any{
+		"Name":      "Google Drive",
+		"Size":      2048,
+		"Published": now.Add(-3 * 24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "google-drive -1 2.0 KiB 3 days ago"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestFuncMapWithoutReferenceTime(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(FuncMap(time.Time{})).Parse(`{{ relativeDate .Published }}`))
+
+	err := tmpl.Execute(&bytes.Buffer{}, map[string]any{"Published": time.Now()})
+	if err == nil {
+		t.Fatal("expected an error without a reference time")
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/conduitio/genfuncs
+
+go 1.24.3
@@ -0,0 +1,216 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genfuncs
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect of the generated schemas.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Patterns of string values accepted by Conduit for typed parameters. Values
+// in a pipeline configuration file can be written as YAML scalars of the
+// matching type or as strings, an empty string is valid for all types.
+const (
+	intPattern   = `^([-+]?[0-9]+)?$`
+	floatPattern = `^([-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][-+]?[0-9]+)?)?$`
+	boolPattern  = `^(1|t|T|TRUE|true|True|0|f|F|FALSE|false|False)?$`
+	// durationPattern matches values accepted by time.ParseDuration.
+	durationPattern = `^([-+]?(0|(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|μs|ms|s|m|h))+))?$`
+)
+
+// Parameter is a configuration parameter of a connector or processor.
+type Parameter struct {
+	Name        string
+	Type        string
+	Default     string
+	Description string
+	Validations []Validation
+}
+
+// Validation is a validation applied to a parameter value.
+type Validation struct {
+	Type  string
+	Value string
+}
+
+// JSONSchema is the subset of JSON Schema used to describe configuration
+// parameters.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 any                    `json:"type,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Not                  *JSONSchema            `json:"not,omitempty"`
+	AllOf                []*JSONSchema          `json:"allOf,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	ExclusiveMinimum     *float64               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64               `json:"exclusiveMaximum,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	PatternProperties    map[string]*JSONSchema `json:"patternProperties,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+}
+
+// SettingsSchema returns a JSON Schema (Draft 2020-12) describing the
+// settings of a connector plugin or processor with the given parameters.
+// Parameters containing a wildcard (e.g. "collections.*.format") are matched
+// using patternProperties, unknown settings are rejected like in Conduit.
+func SettingsSchema(id, title, description string, params []Parameter) (*JSONSchema, error) {
+	noAdditional := false
+	schema := &JSONSchema{
+		Schema:               JSONSchemaDraft,
+		ID:                   id,
+		Title:                title,
+		Description:          description,
+		Type:                 "object",
+		AdditionalProperties: &noAdditional,
+	}
+
+	for _, p := range params {
+		ps, err := ParameterSchema(p)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", p.Name, err)
+		}
+
+		if strings.Contains(p.Name, "*") {
+			if schema.PatternProperties == nil {
+				schema.PatternProperties = map[string]*JSONSchema{}
+			}
+			schema.PatternProperties[wildcardPattern(p.Name)] = ps
+			continue
+		}
+
+		if schema.Properties == nil {
+			schema.Properties = map[string]*JSONSchema{}
+		}
+		schema.Properties[p.Name] = ps
+		if slices.ContainsFunc(p.Validations, func(v Validation) bool { return v.Type == "required" }) {
+			schema.Required = append(schema.Required, p.Name)
+		}
+	}
+	slices.Sort(schema.Required)
+
+	return schema, nil
+}
+
+// ParameterSchema returns the JSON Schema of a single parameter. Validations
+// are mapped to the matching keywords: inclusion to enum, exclusion to not
+// enum, greater-than and less-than to exclusiveMinimum and exclusiveMaximum
+// and regex to pattern. Like every pattern, the regex only applies to values
+// written as strings.
+func ParameterSchema(p Parameter) (*JSONSchema, error) {
+	s := &JSONSchema{Description: p.Description}
+	switch p.Type {
+	case "int":
+		s.Type, s.Pattern = []string{"integer", "string"}, intPattern
+	case "float":
+		s.Type, s.Pattern = []string{"number", "string"}, floatPattern
+	case "bool":
+		s.Type, s.Pattern = []string{"boolean", "string"}, boolPattern
+	case "duration":
+		s.Type, s.Pattern = "string", durationPattern
+	case "string", "file", "time":
+		s.Type = "string"
+	default:
+		return nil, fmt.Errorf("unknown parameter type %q", p.Type)
+	}
+	if p.Default != "" {
+		s.Default = typedValue(p.Type, p.Default)
+	}
+
+	for _, v := range p.Validations {
+		switch v.Type {
+		case "required":
+			// handled by the enclosing object
+		case "greater-than", "less-than":
+			val, err := strconv.ParseFloat(v.Value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %q: %w", v.Type, v.Value, err)
+			}
+			if v.Type == "greater-than" {
+				s.ExclusiveMinimum = &val
+			} else {
+				s.ExclusiveMaximum = &val
+			}
+		case "inclusion":
+			s.Enum = enumValues(p.Type, v.Value)
+		case "exclusion":
+			s.Not = &JSONSchema{Enum: enumValues(p.Type, v.Value)}
+		case "regex":
+			if _, err := regexp.Compile(v.Value); err != nil {
+				return nil, fmt.Errorf("invalid %s value %q: %w", v.Type, v.Value, err)
+			}
+			if s.Pattern != "" {
+				// a schema can't have two patterns, the value has to match
+				// the type pattern as well
+				s.AllOf = append(s.AllOf, &JSONSchema{Pattern: v.Value})
+				continue
+			}
+			s.Pattern = v.Value
+		default:
+			return nil, fmt.Errorf("unknown validation type %q", v.Type)
+		}
+	}
+	return s, nil
+}
+
+// wildcardPattern converts a parameter name containing wildcards into a
+// regular expression, a wildcard matches a single segment of the key.
+func wildcardPattern(name string) string {
+	tokens := strings.Split(name, "*")
+	for i, t := range tokens {
+		tokens[i] = regexp.QuoteMeta(t)
+	}
+	return "^" + strings.Join(tokens, "[^.]+") + "$"
+}
+
+// enumValues splits the comma separated value of an inclusion or exclusion
+// validation into typed values.
+func enumValues(typ, value string) []any {
+	var values []any
+	for _, item := range strings.Split(value, ",") {
+		values = append(values, typedValue(typ, strings.TrimSpace(item)))
+	}
+	return values
+}
+
+// typedValue converts a value into the JSON type of the parameter type, it
+// returns the string if the value can't be converted.
+func typedValue(typ, value string) any {
+	switch typ {
+	case "int":
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case "float":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case "bool":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
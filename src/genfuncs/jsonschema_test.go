@@ -0,0 +1,139 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genfuncs
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestParameterSchema(t *testing.T) {
+	tests := []struct {
+		name  string
+		param Parameter
+		want  string
+	}{{
+		name:  "string with inclusion",
+		param: Parameter{Name: "mode", Type: "string", Default: "snapshot", Validations: []Validation{{Type: "inclusion", Value: "snapshot, cdc"}}},
+		want:  `{"type":"string","default":"snapshot","enum":["snapshot","cdc"]}`,
+	}, {
+		name:  "int with greater-than",
+		param: Parameter{Name: "sdk.batch.size", Type: "int", Default: "0", Validations: []Validation{{Type: "greater-than", Value: "-1"}}},
+		want:  `{"type":["integer","string"],"default":0,"pattern":"` + jsonEscape(intPattern) + `","exclusiveMinimum":-1}`,
+	}, {
+		name:  "bool",
+		param: Parameter{Name: "tls.enabled", Type: "bool", Default: "false", Description: "Enables TLS."},
+		want:  `{"description":"Enables TLS.","type":["boolean","string"],"default":false,"pattern":"` + jsonEscape(boolPattern) + `"}`,
+	}, {
+		name:  "duration",
+		param: Parameter{Name: "sdk.batch.delay", Type: "duration", Default: "0"},
+		want:  `{"type":"string","default":"0","pattern":"` + jsonEscape(durationPattern) + `"}`,
+	}, {
+		name:  "regex and exclusion",
+		param: Parameter{Name: "field", Type: "string", Validations: []Validation{{Type: "required"}, {Type: "regex", Value: "^\\.Payload"}, {Type: "exclusion", Value: ".Position"}}},
+		want:  `{"type":"string","not":{"enum":[".Position"]},"pattern":"^\\.Payload"}`,
+	}, {
+		name:  "int with regex",
+		param: Parameter{Name: "port", Type: "int", Validations: []Validation{{Type: "regex", Value: "^[0-9]{4}$"}}},
+		want:  `{"type":["integer","string"],"allOf":[{"pattern":"^[0-9]{4}$"}],"pattern":"` + jsonEscape(intPattern) + `"}`,
+	}, {
+		name:  "duration with regex",
+		param: Parameter{Name: "timeout", Type: "duration", Validations: []Validation{{Type: "regex", Value: "s$"}}},
+		want:  `{"type":"string","allOf":[{"pattern":"s$"}],"pattern":"` + jsonEscape(durationPattern) + `"}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParameterSchema(tt.param)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParameterSchemaErrors(t *testing.T) {
+	tests := map[string]Parameter{
+		"unknown parameter type":  {Type: "uuid"},
+		"invalid greater-than":    {Type: "int", Validations: []Validation{{Type: "greater-than", Value: "one"}}},
+		"invalid regex":           {Type: "string", Validations: []Validation{{Type: "regex", Value: "("}}},
+		"unknown validation type": {Type: "string", Validations: []Validation{{Type: "email"}}},
+	}
+	for want, p := range tests {
+		if _, err := ParameterSchema(p); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestSettingsSchema(t *testing.T) {
+	s, err := SettingsSchema("https://example.com/demo.schema.json", "demo source", "Demo.", []Parameter{
+		{Name: "url", Type: "string", Validations: []Validation{{Type: "required"}}},
+		{Name: "collections.*.format", Type: "string"},
+		{Name: "auth", Type: "string", Validations: []Validation{{Type: "required"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema","$id":"https://example.com/demo.schema.json","title":"demo source","description":"Demo.","type":"object",` +
+		`"properties":{"auth":{"type":"string"},"url":{"type":"string"}},"patternProperties":{"^collections\\.[^.]+\\.format$":{"type":"string"}},"additionalProperties":false,"required":["auth","url"]}`
+	if string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestValuePatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   []string
+		invalid []string
+	}{
+		{intPattern, []string{"", "0", "-12", "+3"}, []string{"1.5", "ten"}},
+		{floatPattern, []string{"", "1", "1.5", ".5", "-2e10"}, []string{"1.2.3", "NaNs"}},
+		{boolPattern, []string{"", "true", "F", "1"}, []string{"yes", "on"}},
+		{durationPattern, []string{"", "0", "1s", "1h30m", "1.5ms", "-2µs"}, []string{"1", "1d", "s"}},
+	}
+	for _, tt := range tests {
+		re := regexp.MustCompile(tt.pattern)
+		for _, v := range tt.valid {
+			if !re.MatchString(v) {
+				t.Errorf("expected %q to match %s", v, tt.pattern)
+			}
+		}
+		for _, v := range tt.invalid {
+			if re.MatchString(v) {
+				t.Errorf("expected %q not to match %s", v, tt.pattern)
+			}
+		}
+	}
+}
+
+// jsonEscape escapes a string as it is encoded in JSON, without quotes.
+func jsonEscape(s string) string {
+	raw, _ := json.Marshal(s)
+	return string(raw[1 : len(raw)-1])
+}
@@ -0,0 +1,455 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genfuncs
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// mdxCodeFence matches the opening or closing line of a fenced code block.
+var mdxCodeFence = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+
+// MarkdownSegment is a part of a markdown document, either a fenced code block
+// or text outside of code blocks.
+type MarkdownSegment struct {
+	Text string
+	Code bool
+}
+
+// SplitCodeBlocks splits the markdown document into fenced code blocks and
+// the text between them. An unclosed code block extends to the end of the
+// document.
+func SplitCodeBlocks(doc string) []MarkdownSegment {
+	var (
+		segments []MarkdownSegment
+		current  strings.Builder
+		fence    string
+	)
+	flush := func(code bool) {
+		if current.Len() > 0 {
+			segments = append(segments, MarkdownSegment{Text: current.String(), Code: code})
+			current.Reset()
+		}
+	}
+
+	for _, line := range strings.SplitAfter(doc, "\n") {
+		m := mdxCodeFence.FindStringSubmatch(line)
+		switch {
+		case fence == "" && m != nil:
+			flush(false)
+			fence = m[1]
+			current.WriteString(line)
+		case fence != "" && isClosingFence(line, fence):
+			current.WriteString(line)
+			flush(true)
+			fence = ""
+		default:
+			current.WriteString(line)
+		}
+	}
+	flush(fence != "")
+	return segments
+}
+
+// isClosingFence returns true if the line closes a code block opened with
+// fence, i.e. it contains only the fence character, at least as often as the
+// opening fence.
+func isClosingFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return len(trimmed) >= len(fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// EscapeMDX makes a markdown string taken from a specification safe to embed
+// in an MDX page. Text outside of code blocks is escaped with EscapeMDXText.
+func EscapeMDX(s string) string {
+	var sb strings.Builder
+	for _, segment := range SplitCodeBlocks(s) {
+		if segment.Code {
+			sb.WriteString(segment.Text)
+			continue
+		}
+		sb.WriteString(EscapeMDXText(segment.Text))
+	}
+	return sb.String()
+}
+
+// FrontMatterString quotes the string so it can be used as a value in the
+// YAML front matter. JSON strings are valid YAML strings.
+func FrontMatterString(s string) string {
+	raw, _ := json.Marshal(s) // marshaling a string can't fail
+	return string(raw)
+}
+
+// EscapeMDXText escapes characters that MDX would interpret as JSX or
+// JavaScript expressions (`{`, `}`, `<`), so that markdown text is rendered
+// literally. Inline code spans are left untouched, MDX renders them as is.
+func EscapeMDXText(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); {
+		if s[i] == '`' {
+			// copy the inline code span including its delimiters
+			n := countRun(s[i:], '`')
+			delim := s[i : i+n]
+			if end := strings.Index(s[i+n:], delim); end >= 0 {
+				sb.WriteString(s[i : i+n+end+n])
+				i += n + end + n
+				continue
+			}
+			sb.WriteString(delim)
+			i += n
+			continue
+		}
+
+		switch s[i] {
+		case '\\':
+			// keep existing escapes as they are
+			if i+1 < len(s) {
+				sb.WriteString(s[i : i+2])
+				i += 2
+				continue
+			}
+			sb.WriteByte(s[i])
+		case '{', '}', '<':
+			sb.WriteByte('\\')
+			sb.WriteByte(s[i])
+		default:
+			sb.WriteByte(s[i])
+		}
+		i++
+	}
+	return sb.String()
+}
+
+func countRun(s string, c byte) int {
+	n := 0
+	for n < len(s) && s[n] == c {
+		n++
+	}
+	return n
+}
+
+// MDXProblem is a syntax problem found in an MDX document.
+type MDXProblem struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p MDXProblem) String() string {
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+}
+
+// LintMDX checks the MDX document for the syntax errors that upstream content
+// usually causes: unclosed expressions, invalid or unclosed JSX tags, HTML
+// comments and a `<` that doesn't start a tag. It is not a complete MDX parser,
+// code blocks, front matter and import/export statements are skipped.
+func LintMDX(doc string) []MDXProblem {
+	l := &mdxLinter{}
+	l.lint(doc)
+	return l.problems
+}
+
+type mdxPosition struct {
+	line, column int
+}
+
+type mdxTag struct {
+	name string
+	pos  mdxPosition
+}
+
+type mdxLinter struct {
+	// text contains the lines that need to be checked, other lines are
+	// replaced with empty lines to keep line numbers intact.
+	text []string
+	line int
+	col  int
+
+	tags     []mdxTag
+	problems []MDXProblem
+}
+
+func (l *mdxLinter) lint(doc string) {
+	l.text = mdxTextLines(doc)
+	for l.line = 0; l.line < len(l.text); l.line++ {
+		for l.col = 0; l.col < len(l.text[l.line]); {
+			l.step()
+		}
+	}
+	for _, tag := range l.tags {
+		l.problemAt(tag.pos, fmt.Sprintf("tag <%s> is never closed", tag.name))
+	}
+}
+
+// mdxTextLines returns the lines of the document, with lines that are not
+// parsed as markdown (front matter, code blocks, import and export statements)
+// replaced by empty lines. Code blocks with the info string mdx-code-block are
+// rendered as MDX by Docusaurus, so they are kept.
+func mdxTextLines(doc string) []string {
+	lines := strings.Split(doc, "\n")
+	out := make([]string, len(lines))
+	start := 0
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	// mdxFence is the fence of the open mdx-code-block, its content is
+	// checked, only the fence lines are skipped.
+	fence, mdxFence := "", ""
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		m := mdxCodeFence.FindStringSubmatch(line)
+		switch {
+		case mdxFence != "" && isClosingFence(line, mdxFence):
+			mdxFence = ""
+		case fence == "" && m != nil:
+			if strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), m[1][:1])) == "mdx-code-block" {
+				mdxFence = m[1]
+			} else {
+				fence = m[1]
+			}
+		case fence != "":
+			if isClosingFence(line, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(line, "import ") || strings.HasPrefix(line, "export "):
+		default:
+			out[i] = line
+		}
+	}
+	return out
+}
+
+func (l *mdxLinter) pos() mdxPosition {
+	return mdxPosition{line: l.line, column: l.col}
+}
+
+func (l *mdxLinter) problemAt(pos mdxPosition, msg string) {
+	l.problems = append(l.problems, MDXProblem{Line: pos.line + 1, Column: pos.column + 1, Message: msg})
+}
+
+// peek returns the current character, or 0 at the end of a line.
+func (l *mdxLinter) peek() byte {
+	if l.col < len(l.text[l.line]) {
+		return l.text[l.line][l.col]
+	}
+	return 0
+}
+
+// next advances to the next character, continuing on the next line at the end
+// of a line. It returns false at the end of the document.
+func (l *mdxLinter) next() bool {
+	l.col++
+	if l.col > len(l.text[l.line]) {
+		if l.line+1 >= len(l.text) {
+			return false
+		}
+		l.line++
+		l.col = 0
+	}
+	return true
+}
+
+// step checks the markdown text at the current position and advances past
+// one construct.
+func (l *mdxLinter) step() {
+	line := l.text[l.line]
+	switch c := line[l.col]; c {
+	case '\\':
+		l.col += 2
+	case '`':
+		l.inlineCode()
+	case '{':
+		l.expression()
+	case '<':
+		if strings.HasSuffix(line[:l.col], "](") {
+			// link destination in angle brackets, e.g. [a](<b c>)
+			if end := strings.IndexByte(line[l.col:], '>'); end >= 0 {
+				l.col += end + 1
+				return
+			}
+		}
+		l.tag()
+	default:
+		l.col++
+	}
+}
+
+// inlineCode skips the inline code span starting at the current position.
+// Code spans can continue on the following lines of the paragraph. If the
+// span is never closed, the backticks are literal text.
+func (l *mdxLinter) inlineCode() {
+	line := l.text[l.line]
+	n := countRun(line[l.col:], '`')
+	delim := line[l.col : l.col+n]
+	if end := strings.Index(line[l.col+n:], delim); end >= 0 {
+		l.col += n + end + n
+		return
+	}
+	for i := l.line + 1; i < len(l.text) && strings.TrimSpace(l.text[i]) != ""; i++ {
+		if end := strings.Index(l.text[i], delim); end >= 0 {
+			l.line, l.col = i, end+n
+			return
+		}
+	}
+	l.col += n
+}
+
+// expression skips a JavaScript expression starting at the current `{`,
+// reporting it if it is never closed.
+func (l *mdxLinter) expression() {
+	start := l.pos()
+	depth := 0
+	var quote byte
+	for {
+		c := l.peek()
+		switch {
+		case quote != 0 && c == '\\':
+			l.next()
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				l.col++
+				return
+			}
+		}
+		if !l.next() {
+			l.problemAt(start, "expression starting with `{` is never closed, escape it as `\\{`")
+			return
+		}
+	}
+}
+
+// tag checks the JSX tag starting at the current `<`.
+func (l *mdxLinter) tag() {
+	start := l.pos()
+	l.col++
+	switch c := l.peek(); {
+	case c == '!':
+		l.problemAt(start, "HTML comments are not supported in MDX")
+		l.col++
+		return
+	case c == '/':
+		l.col++
+		l.closingTag(start)
+		return
+	case c == '>':
+		// fragment
+		l.col++
+		l.tags = append(l.tags, mdxTag{pos: start})
+		return
+	case !isTagNameStart(c):
+		l.problemAt(start, "`<` does not start a tag, escape it as `\\<`")
+		return
+	}
+
+	name := l.tagName()
+	for {
+		c := l.peek()
+		switch {
+		case c == '>':
+			l.col++
+			l.tags = append(l.tags, mdxTag{name: name, pos: start})
+			return
+		case c == '/' && l.col+1 < len(l.text[l.line]) && l.text[l.line][l.col+1] == '>':
+			l.col += 2
+			return
+		case c == '{':
+			l.expression()
+			continue
+		case c == '"' || c == '\'':
+			if !l.skipString(c) {
+				l.problemAt(start, fmt.Sprintf("attribute value of tag <%s> is never closed", name))
+				return
+			}
+		case c == 0 || c == ' ' || c == '\t' || c == '=' || isTagNameChar(c):
+		default:
+			l.problemAt(start, fmt.Sprintf("unexpected character %q in tag <%s>, escape the `<` as `\\<` if this is not a tag", c, name))
+			return
+		}
+		if !l.next() {
+			l.problemAt(start, fmt.Sprintf("tag <%s> is not terminated with `>`", name))
+			return
+		}
+	}
+}
+
+func (l *mdxLinter) closingTag(start mdxPosition) {
+	name := ""
+	if isTagNameStart(l.peek()) {
+		name = l.tagName()
+	}
+	if l.peek() != '>' {
+		l.problemAt(start, fmt.Sprintf("closing tag </%s is not terminated with `>`", name))
+		return
+	}
+	l.col++
+
+	if len(l.tags) == 0 {
+		l.problemAt(start, fmt.Sprintf("unexpected closing tag </%s>", name))
+		return
+	}
+	open := l.tags[len(l.tags)-1]
+	if open.name != name {
+		l.problemAt(start, fmt.Sprintf("unexpected closing tag </%s>, expected </%s> for the tag opened at %d:%d",
+			name, open.name, open.pos.line+1, open.pos.column+1))
+		return
+	}
+	l.tags = l.tags[:len(l.tags)-1]
+}
+
+func (l *mdxLinter) tagName() string {
+	line := l.text[l.line]
+	start := l.col
+	for l.col < len(line) && isTagNameChar(line[l.col]) {
+		l.col++
+	}
+	return line[start:l.col]
+}
+
+// skipString advances to the closing quote of the string starting at the
+// current position. It returns false if the string is never closed.
+func (l *mdxLinter) skipString(quote byte) bool {
+	for l.next() {
+		if l.peek() == quote {
+			return true
+		}
+	}
+	return false
+}
+
+func isTagNameStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c == '$'
+}
+
+func isTagNameChar(c byte) bool {
+	return isTagNameStart(c) || (c >= '0' && c <= '9') || c == '-' || c == '.' || c == ':'
+}
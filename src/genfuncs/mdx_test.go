@@ -0,0 +1,103 @@
+// Copyright © 2025 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genfuncs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLintMDX(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []MDXProblem
+	}{{
+		name: "valid",
+		doc: "---\ntitle: \"<x>\"\n---\n\nimport Tabs from '@theme/Tabs';\n\n" +
+			"<Tabs groupId=\"a\">\n  <TabItem value=\"b\" label={'c'} default>\n```yaml\n<not a tag> {\n```\n" +
+			"  </TabItem>\n</Tabs>\n\n<Box sx={{\n  a: '}',\n}} />\n\n" +
+			"Use `<foo> {` or ``{`\n<bar>`` and \\{escaped\\} \\<b>, [link](<a b>).\n",
+	}, {
+		name: "unclosed expression",
+		doc:  "text\nsee {Foo\n",
+		want: []MDXProblem{{Line: 2, Column: 5, Message: "expression starting with `{` is never closed, escape it as `\\{`"}},
+	}, {
+		name: "unclosed tag",
+		doc:  "- : <empty>\n",
+		want: []MDXProblem{{Line: 1, Column: 5, Message: "tag <empty> is never closed"}},
+	}, {
+		name: "less than",
+		doc:  "files <= 4MB\n",
+		want: []MDXProblem{{Line: 1, Column: 7, Message: "`<` does not start a tag, escape it as `\\<`"}},
+	}, {
+		name: "autolink",
+		doc:  "see <https://conduit.io>\n",
+		want: []MDXProblem{{Line: 1, Column: 5, Message: "unexpected character '/' in tag <https:>, escape the `<` as `\\<` if this is not a tag"}},
+	}, {
+		name: "HTML comment",
+		doc:  "<!-- readmegen:description -->\n",
+		want: []MDXProblem{{Line: 1, Column: 1, Message: "HTML comments are not supported in MDX"}},
+	}, {
+		name: "mismatched closing tag",
+		doc:  "<details>\n</summary>\n",
+		want: []MDXProblem{
+			{Line: 2, Column: 1, Message: "unexpected closing tag </summary>, expected </details> for the tag opened at 1:1"},
+			{Line: 1, Column: 1, Message: "tag <details> is never closed"},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LintMDX(tt.doc)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEscapeMDXPassesLint(t *testing.T) {
+	in := "- : <empty>\nFor files <= 4MB use {name} <https://foo.io>.\n\n```go\nif a < b {\n```\n"
+	want := "- : \\<empty>\nFor files \\<= 4MB use \\{name\\} \\<https://foo.io>.\n\n```go\nif a < b {\n```\n"
+
+	got := EscapeMDX(in)
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if problems := LintMDX(got); len(problems) > 0 {
+		t.Fatalf("expected no problems, got %v", problems)
+	}
+}
+
+func TestSplitCodeBlocks(t *testing.T) {
+	doc := "text\n````md\n```go\ninner\n```\n````\nmore\n~~~\nunclosed\n"
+	want := []MarkdownSegment{
+		{Text: "text\n"},
+		{Text: "````md\n```go\ninner\n```\n````\n", Code: true},
+		{Text: "more\n"},
+		{Text: "~~~\nunclosed\n", Code: true},
+	}
+
+	got := SplitCodeBlocks(doc)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d segments, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("segment %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed category.tmpl
+var categoryTmpl string
+
+// categoryConfig configures the categories the processors are grouped by in
+// the documentation, see categories.yaml.
+type categoryConfig struct {
+	Categories []category `yaml:"categories"`
+	// Processors maps processor names to category IDs, it takes precedence
+	// over the prefixes of the categories.
+	Processors map[string]string `yaml:"processors"`
+}
+
+type category struct {
+	ID          string   `yaml:"id"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	Prefixes    []string `yaml:"prefixes"`
+
+	// Position is the position of the category in the sidebar, it's the
+	// index of the category in the config. Specs are the processors in the
+	// category sorted by name. Both are set by categoryConfig.assign.
+	Position int             `yaml:"-"`
+	Specs    []processorSpec `yaml:"-"`
+	// Positions maps processor names to the position of their page in the
+	// sidebar, they are set by pageIndex.assignPositions.
+	Positions map[string]int `yaml:"-"`
+}
+
+// readCategoryConfig reads and validates the category configuration.
+func readCategoryConfig(path string) (categoryConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return categoryConfig{}, fmt.Errorf("failed to read category config: %w", err)
+	}
+
+	var cfg categoryConfig
+	dec := yaml.NewDecoder(strings.NewReader(string(raw)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return categoryConfig{}, fmt.Errorf("failed to parse category config %s: %w", path, err)
+	}
+
+	var errs []error
+	ids := make(map[string]bool)
+	for i, c := range cfg.Categories {
+		switch {
+		case c.ID == "" || c.Title == "":
+			errs = append(errs, fmt.Errorf("categories[%d]: id and title are required", i))
+		case ids[c.ID]:
+			errs = append(errs, fmt.Errorf("categories[%d]: duplicate id %q", i, c.ID))
+		}
+		ids[c.ID] = true
+	}
+	for name, id := range cfg.Processors {
+		if !ids[id] {
+			errs = append(errs, fmt.Errorf("processors.%s: unknown category %q", name, id))
+		}
+	}
+	if len(errs) > 0 {
+		return categoryConfig{}, fmt.Errorf("invalid category config %s:\n%w", path, errors.Join(errs...))
+	}
+	return cfg, nil
+}
+
+// categoryOf returns the ID of the category the processor belongs to.
+func (cfg categoryConfig) categoryOf(name string) (string, bool) {
+	if id, ok := cfg.Processors[name]; ok {
+		return id, true
+	}
+	for _, c := range cfg.Categories {
+		for _, prefix := range c.Prefixes {
+			if strings.HasPrefix(name, prefix) {
+				return c.ID, true
+			}
+		}
+	}
+	return "", false
+}
+
+// assign groups the processors by category. Categories are positioned in the
+// configured order and processors are sorted by name. Categories without
+// processors are omitted, they keep their position, so the other categories
+// don't move when a category becomes empty.
+func (cfg categoryConfig) assign(specs []processorSpec) ([]category, error) {
+	byID := make(map[string][]processorSpec)
+	var unassigned []string
+	for _, spec := range specs {
+		id, ok := cfg.categoryOf(spec.Specification.Name)
+		if !ok {
+			unassigned = append(unassigned, spec.Specification.Name)
+			continue
+		}
+		byID[id] = append(byID[id], spec)
+	}
+	if len(unassigned) > 0 {
+		return nil, fmt.Errorf("processors without category, add them to the category config: %s", strings.Join(unassigned, ", "))
+	}
+
+	var categories []category
+	for i, c := range cfg.Categories {
+		if len(byID[c.ID]) == 0 {
+			continue
+		}
+		c.Position = i
+		c.Specs = slices.SortedFunc(slices.Values(byID[c.ID]), func(a, b processorSpec) int {
+			return strings.Compare(a.Specification.Name, b.Specification.Name)
+		})
+		categories = append(categories, c)
+	}
+	return categories, nil
+}
+
+// pageIndex records the sidebar position of each processor page within its
+// category. It is persisted between runs, so positions stay stable and adding
+// a processor doesn't move the other pages of its category.
+type pageIndex struct {
+	// Positions maps category IDs to the positions of the processor pages in
+	// the category by processor name.
+	Positions map[string]map[string]int `json:"positions"`
+}
+
+func readPageIndex(path string) (pageIndex, error) {
+	index := pageIndex{Positions: map[string]map[string]int{}}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	} else if err != nil {
+		return pageIndex{}, fmt.Errorf("failed to read page index: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return pageIndex{}, fmt.Errorf("failed to parse page index %s: %w", path, err)
+	}
+	if index.Positions == nil {
+		index.Positions = map[string]map[string]int{}
+	}
+	return index, nil
+}
+
+func (pi pageIndex) write(path string) error {
+	raw, err := json.MarshalIndent(pi, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal page index: %w", err)
+	}
+	return os.WriteFile(path, append(raw, '\n'), 0644)
+}
+
+// assignPositions sets the sidebar positions of the processors in the
+// categories and returns the updated page index. Processors keep their
+// position, new processors (including processors moved to another category)
+// are appended in the order of their names. Removed processors are dropped
+// from the index, which can leave gaps in the positions.
+func (pi pageIndex) assignPositions(categories []category) pageIndex {
+	next := pageIndex{Positions: make(map[string]map[string]int, len(categories))}
+	for i := range categories {
+		c := &categories[i]
+		previous := pi.Positions[c.ID]
+		c.Positions = make(map[string]int, len(c.Specs))
+
+		last := -1
+		var added []string
+		for _, spec := range c.Specs {
+			name := spec.Specification.Name
+			if pos, ok := previous[name]; ok {
+				c.Positions[name] = pos
+				last = max(last, pos)
+				continue
+			}
+			added = append(added, name)
+		}
+		// c.Specs is sorted by name
+		for _, name := range added {
+			last++
+			c.Positions[name] = last
+		}
+		next.Positions[c.ID] = c.Positions
+	}
+	return next
+}
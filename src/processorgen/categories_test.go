@@ -0,0 +1,153 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCategoryConfigAssign(t *testing.T) {
+	cfg := categoryConfig{
+		Categories: []category{
+			{ID: "fields", Title: "Fields", Prefixes: []string{"field."}},
+			{ID: "encoding", Title: "Encoding", Prefixes: []string{"json.", "base64."}},
+			{ID: "ai", Title: "AI", Prefixes: []string{"openai."}},
+			{ID: "routing", Title: "Routing"},
+		},
+		Processors: map[string]string{
+			"filter": "routing",
+			// takes precedence over the prefix
+			"json.special": "routing",
+		},
+	}
+	spec := func(name string) processorSpec {
+		return processorSpec{Specification: specification{Name: name}}
+	}
+
+	got, err := cfg.assign([]processorSpec{
+		spec("json.encode"),
+		spec("filter"),
+		spec("field.set"),
+		spec("base64.decode"),
+		spec("json.special"),
+		spec("field.exclude"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make(map[string][]string)
+	var ids []string
+	var positions []int
+	for _, c := range got {
+		ids = append(ids, c.ID)
+		positions = append(positions, c.Position)
+		for _, s := range c.Specs {
+			names[c.ID] = append(names[c.ID], s.Specification.Name)
+		}
+	}
+	// categories keep the configured order, empty ones are omitted
+	if want := []string{"fields", "encoding", "routing"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected categories %q, got %q", want, ids)
+	}
+	// empty categories keep their position
+	if want := []int{0, 1, 3}; !reflect.DeepEqual(positions, want) {
+		t.Errorf("expected positions %v, got %v", want, positions)
+	}
+	wantNames := map[string][]string{
+		"fields":   {"field.exclude", "field.set"},
+		"encoding": {"base64.decode", "json.encode"},
+		"routing":  {"filter", "json.special"},
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("expected %q, got %q", wantNames, names)
+	}
+
+	_, err = cfg.assign([]processorSpec{spec("field.set"), spec("unknown"), spec("custom.javascript")})
+	want := "processors without category, add them to the category config: unknown, custom.javascript"
+	if err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
+
+func TestPageIndexAssignPositions(t *testing.T) {
+	newCategory := func(id string, names ...string) category {
+		c := category{ID: id}
+		for _, name := range names {
+			c.Specs = append(c.Specs, processorSpec{Specification: specification{Name: name}})
+		}
+		return c
+	}
+
+	tests := []struct {
+		name       string
+		previous   map[string]map[string]int
+		categories []category
+		want       map[string]map[string]int
+	}{{
+		name:       "no previous index",
+		categories: []category{newCategory("fields", "field.exclude", "field.set"), newCategory("routing", "filter")},
+		want: map[string]map[string]int{
+			"fields":  {"field.exclude": 0, "field.set": 1},
+			"routing": {"filter": 0},
+		},
+	}, {
+		name:       "new processors are appended",
+		previous:   map[string]map[string]int{"fields": {"field.set": 0, "field.rename": 1}},
+		categories: []category{newCategory("fields", "field.convert", "field.exclude", "field.rename", "field.set")},
+		want: map[string]map[string]int{
+			"fields": {"field.set": 0, "field.rename": 1, "field.convert": 2, "field.exclude": 3},
+		},
+	}, {
+		name: "removed and moved processors",
+		previous: map[string]map[string]int{
+			"fields":  {"field.set": 0, "field.rename": 1, "field.exclude": 2},
+			"routing": {"filter": 0},
+			"removed": {"custom.javascript": 0},
+		},
+		categories: []category{newCategory("fields", "field.exclude", "field.set"), newCategory("routing", "field.rename", "filter")},
+		want: map[string]map[string]int{
+			"fields":  {"field.set": 0, "field.exclude": 2},
+			"routing": {"filter": 0, "field.rename": 1},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := pageIndex{Positions: tt.previous}
+			got := index.assignPositions(tt.categories)
+			if !reflect.DeepEqual(got.Positions, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got.Positions)
+			}
+			for _, c := range tt.categories {
+				if !reflect.DeepEqual(c.Positions, tt.want[c.ID]) {
+					t.Errorf("category %s: expected positions %v, got %v", c.ID, tt.want[c.ID], c.Positions)
+				}
+			}
+		})
+	}
+}
+
+func TestReadPageIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "processor-pages.json")
+
+	// a missing index is empty
+	got, err := readPageIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Positions) != 0 {
+		t.Errorf("expected an empty index, got %v", got.Positions)
+	}
+
+	want := pageIndex{Positions: map[string]map[string]int{"fields": {"field.set": 0}}}
+	if err := want.write(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err = readPageIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
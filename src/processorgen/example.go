@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/conduitio/genfuncs"
+	"gopkg.in/yaml.v3"
+)
+
+// exampleConfig is the pipeline configuration file shown in the processor
+// docs, it contains a single processor and a placeholder for connectors.
+type exampleConfig struct {
+	Version   exampleVersion    `yaml:"version"`
+	Pipelines []examplePipeline `yaml:"pipelines"`
+}
+
+// exampleVersion is written as a number (e.g. 2.2) like in the rest of the
+// documentation.
+type exampleVersion string
+
+func (v exampleVersion) MarshalYAML() (any, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: string(v)}, nil
+}
+
+type examplePipeline struct {
+	ID         string             `yaml:"id"`
+	Status     string             `yaml:"status"`
+	Connectors exampleConnectors  `yaml:"connectors"`
+	Processors []exampleProcessor `yaml:"processors"`
+}
+
+// exampleConnectors is a placeholder for the connectors of the example
+// pipeline, it's written as an empty value with a comment.
+type exampleConnectors struct{}
+
+func (exampleConnectors) MarshalYAML() (any, error) {
+	return &yaml.Node{
+		Kind:        yaml.ScalarNode,
+		Tag:         "!!null",
+		LineComment: "define source and destination ...",
+	}, nil
+}
+
+type exampleProcessor struct {
+	ID       string          `yaml:"id"`
+	Plugin   string          `yaml:"plugin"`
+	Settings exampleSettings `yaml:"settings,omitempty"`
+}
+
+// exampleSetting is a processor setting, the comment is written above the key.
+type exampleSetting struct {
+	Key     string
+	Value   string
+	Comment string
+}
+
+// exampleSettings keeps the order of the settings and writes all values as
+// strings, because that's how Conduit parses them.
+type exampleSettings []exampleSetting
+
+func (s exampleSettings) MarshalYAML() (any, error) {
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	for _, setting := range s {
+		value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: setting.Value, Style: yaml.DoubleQuotedStyle}
+		if strings.Contains(setting.Value, "\n") {
+			value.Style = yaml.LiteralStyle
+		}
+		n.Content = append(n.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: setting.Key, HeadComment: setting.Comment},
+			value,
+		)
+	}
+	return n, nil
+}
+
+func (s *exampleSettings) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: settings must be a mapping", n.Line)
+	}
+	*s = nil
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return fmt.Errorf("line %d: setting %q must be a scalar value", value.Line, key.Value)
+		}
+		*s = append(*s, exampleSetting{Key: key.Value, Value: value.Value})
+	}
+	return nil
+}
+
+// examplePipelineYAML returns an example pipeline configuration containing the
+// processor with all parameters set to their default values. The description
+// and type of each parameter is written as a comment above it.
+func examplePipelineYAML(spec specification) (string, error) {
+	var settings exampleSettings
+	for _, name := range slices.Sorted(maps.Keys(spec.Parameters)) {
+		param := spec.Parameters[name]
+		settings = append(settings, exampleSetting{
+			Key:     name,
+			Value:   param.Default,
+			Comment: genfuncs.ExampleComment(param.Description, param.Type),
+		})
+	}
+	return marshalExample(spec.Name, settings)
+}
+
+// exampleConfigYAML returns an example pipeline configuration containing the
+// processor with the configuration of one of its examples.
+func exampleConfigYAML(name string, config map[string]string) (string, error) {
+	var settings exampleSettings
+	for _, key := range slices.Sorted(maps.Keys(config)) {
+		settings = append(settings, exampleSetting{Key: key, Value: config[key]})
+	}
+	return marshalExample(name, settings)
+}
+
+// marshalExample encodes the example pipeline and parses it again to make sure
+// it's valid YAML that contains the same processor settings.
+func marshalExample(plugin string, settings exampleSettings) (string, error) {
+	cfg := exampleConfig{
+		Version: "2.2",
+		Pipelines: []examplePipeline{{
+			ID:     "example",
+			Status: "running",
+			Processors: []exampleProcessor{{
+				ID:       "example",
+				Plugin:   plugin,
+				Settings: settings,
+			}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return "", fmt.Errorf("failed to marshal example pipeline: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("failed to marshal example pipeline: %w", err)
+	}
+
+	if err := checkExample(buf.Bytes(), cfg); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// checkExample parses the generated example pipeline and checks that it
+// contains the processors and settings of the original configuration.
+func checkExample(raw []byte, want exampleConfig) error {
+	var got exampleConfig
+	if err := yaml.Unmarshal(raw, &got); err != nil {
+		return fmt.Errorf("generated example pipeline is invalid: %w\n%s", err, raw)
+	}
+
+	equal := got.Version == want.Version && len(got.Pipelines) == len(want.Pipelines)
+	for i := 0; equal && i < len(got.Pipelines); i++ {
+		gotProcessors, wantProcessors := got.Pipelines[i].Processors, want.Pipelines[i].Processors
+		equal = len(gotProcessors) == len(wantProcessors)
+		for j := 0; equal && j < len(gotProcessors); j++ {
+			g, w := gotProcessors[j], wantProcessors[j]
+			equal = g.ID == w.ID && g.Plugin == w.Plugin &&
+				slices.EqualFunc(g.Settings, w.Settings, func(a, b exampleSetting) bool {
+					return a.Key == b.Key && a.Value == b.Value
+				})
+		}
+	}
+	if !equal {
+		return fmt.Errorf("generated example pipeline does not round-trip:\n%s", raw)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+module github.com/conduitio/processorgen
+
+go 1.24.3
+
+require (
+	github.com/conduitio/genfuncs v0.0.0-00010101000000-000000000000
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/conduitio/genfuncs => ../genfuncs
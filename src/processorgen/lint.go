@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/conduitio/genfuncs"
+)
+
+// lintDocs checks the generated processor pages in outputPath for MDX syntax
+// errors and reports the processor and specification field causing them. Pages
+// are looked up in the folder of their category. It returns the number of pages
+// with problems.
+func lintDocs(inputPath, outputPath string, categories categoryConfig) (int, error) {
+	procs, err := readSpecs(inputPath)
+	if err != nil {
+		return 0, err
+	}
+
+	invalid := 0
+	for _, proc := range procs {
+		procName := proc.Specification.Name
+
+		categoryID, ok := categories.categoryOf(procName)
+		if !ok {
+			return 0, fmt.Errorf("processor %s has no category, add it to the category config", procName)
+		}
+		path := filepath.Join(outputPath, categoryID, procName+".mdx")
+		page, err := os.ReadFile(path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		problems := genfuncs.LintMDX(string(page))
+		if len(problems) == 0 {
+			continue
+		}
+
+		invalid++
+		fields, err := procFields(proc)
+		if err != nil {
+			return 0, err
+		}
+		lines := strings.Split(string(page), "\n")
+		log.Printf("❌ %s (%s)", procName, path)
+		for _, p := range problems {
+			log.Printf("  - %v (%s)", p, locateField(fields, lines[p.Line-1], p.Column))
+		}
+	}
+	return invalid, nil
+}
+
+// specField is a string field of a processor specification, as it is
+// rendered in the page.
+type specField struct {
+	path  string
+	value string
+}
+
+// procFields returns all string fields of the specification, the paths match
+// the fields in the specification file.
+func procFields(proc processorSpec) ([]specField, error) {
+	raw, err := json.Marshal(proc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", proc.File, err)
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", proc.File, err)
+	}
+	return specFields("", v), nil
+}
+
+// specFields returns all string fields in the decoded JSON value, sorted by
+// path.
+func specFields(path string, v any) []specField {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		var fields []specField
+		for _, k := range keys {
+			fields = append(fields, specFields(strings.TrimPrefix(path+"."+k, "."), v[k])...)
+		}
+		return fields
+	case []any:
+		var fields []specField
+		for i, item := range v {
+			fields = append(fields, specFields(fmt.Sprintf("%s[%d]", path, i), item)...)
+		}
+		return fields
+	case string:
+		return []specField{{path: path, value: genfuncs.EscapeMDX(v)}}
+	default:
+		return nil
+	}
+}
+
+// locateField returns a description of the field that contains the text at
+// the given column of the line.
+func locateField(fields []specField, line string, column int) string {
+	// Search for the text around the problem, then for the text starting at
+	// the problem, in case the field starts at the problem.
+	idx := min(max(column-1, 0), len(line))
+	for _, snippet := range []string{
+		line[max(idx-20, 0):min(idx+20, len(line))],
+		line[idx:min(idx+10, len(line))],
+	} {
+		snippet = strings.TrimSpace(snippet)
+		if snippet == "" {
+			continue
+		}
+		for _, f := range fields {
+			if strings.Contains(f.value, snippet) {
+				return "in " + f.path
+			}
+		}
+	}
+	return "not found in the specification, check the template"
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed llms.tmpl
+var llmsTmpl string
+
+const (
+	// siteURL is the URL of the documentation site, links in the llms.txt
+	// files are absolute, since the files are read outside of the site.
+	siteURL = "https://conduitdata.io"
+	// processorsURL is the URL under which the processor pages are published.
+	processorsURL = "/docs/using/processors/builtin/"
+
+	// llmsFile is the concise index of all processors.
+	llmsFile = "processors.txt"
+	// llmsFullFile contains all processors including their parameters.
+	llmsFullFile = "processors-full.txt"
+)
+
+// generateLLMs renders the processor sections of llms.txt and llms-full.txt
+// from the specifications in inputPath. The files are written to outputPath,
+// or, if check is true, compared with the files in outputPath. It returns the
+// paths of the files that are stale.
+func generateLLMs(inputPath, outputPath string, check bool) ([]string, error) {
+	procs, err := readSpecs(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := template.New("llms").Funcs(template.FuncMap{
+		"siteURL":       func() string { return siteURL },
+		"processorsURL": func() string { return processorsURL },
+		"oneLine":       oneLine,
+		"llmsParameter": llmsParameter,
+	}).Option("missingkey=zero").Parse(llmsTmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse llms template: %w", err)
+	}
+
+	var stale []string
+	for _, f := range []struct{ name, template string }{
+		{name: llmsFile, template: "llms"},
+		{name: llmsFullFile, template: "llms-full"},
+	} {
+		name := f.name
+		var buf bytes.Buffer
+		if err := t.ExecuteTemplate(&buf, f.template, procs); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", name, err)
+		}
+		// links need to be absolute, since the files are read outside of the site
+		content := strings.ReplaceAll(buf.String(), "conduit.io", "conduitdata.io")
+		content = strings.ReplaceAll(content, "](/docs/", "]("+siteURL+"/docs/")
+
+		path := filepath.Join(outputPath, name)
+		if !check {
+			log.Printf("📄 generating %s", path)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			continue
+		}
+
+		log.Printf("🔍 checking %s", path)
+		existing, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if string(existing) != content {
+			stale = append(stale, path)
+		}
+	}
+	return stale, nil
+}
+
+// oneLine collapses whitespace, so multiline text fits into a list item.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// llmsParameter describes a parameter in a single line, including its exact
+// key, type, default value and validations.
+func llmsParameter(name string, param parameter) string {
+	details := []string{param.Type}
+	if param.Default != "" {
+		details = append(details, fmt.Sprintf("default: `%s`", oneLine(param.Default)))
+	}
+	for _, v := range param.Validations {
+		details = append(details, describeValidation(v.Type, v.Value))
+	}
+
+	line := fmt.Sprintf("`%s` (%s)", name, strings.Join(details, "; "))
+	if description := oneLine(param.Description); description != "" {
+		line += ": " + description
+	}
+	return line
+}
+
+// describeValidation returns a human-readable description of a validation.
+func describeValidation(typ, value string) string {
+	switch typ {
+	case "required":
+		return "required"
+	case "greater-than":
+		return "greater than " + value
+	case "less-than":
+		return "less than " + value
+	case "inclusion":
+		return "one of: " + strings.ReplaceAll(value, ",", ", ")
+	case "exclusion":
+		return "not one of: " + strings.ReplaceAll(value, ",", ", ")
+	case "regex":
+		return "matches regex " + value
+	default:
+		return strings.TrimSpace(typ + " " + value)
+	}
+}
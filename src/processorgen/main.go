@@ -1,8 +1,11 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -10,6 +13,9 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
+
+	"github.com/conduitio/genfuncs"
 )
 
 //go:embed mdx.tmpl
@@ -21,16 +27,93 @@ func main() {
 	// parse the command arguments
 	args := parseFlags()
 
-	log.Printf("🕵️parsing mdx template")
-	t, err := template.New("").Funcs(funcMap).Option("missingkey=zero").Parse(tmpl)
-	if err != nil {
-		log.Fatalf("error: failed to parse mdx template: %v", err)
+	if args.lint {
+		log.Printf("🔍 linting generated pages in %v", args.output)
+		categories, err := readCategoryConfig(args.categories)
+		if err != nil {
+			log.Fatalf("error: %v", err)
+		}
+		invalid, err := lintDocs(args.input, args.output, categories)
+		if err != nil {
+			log.Fatalf("error: %v", err)
+		}
+		if invalid > 0 {
+			log.Fatalf("error: found problems in %d page(s)", invalid)
+		}
+		log.Printf("✅  done")
+		return
+	}
+
+	if args.conduit != "" {
+		log.Printf("🔍 comparing specs in %v with %v", args.input, args.conduit)
+		stale, err := syncSpecs(context.Background(), args.input, args.conduit, args.processorsPath, args.update)
+		if err != nil {
+			log.Fatalf("error: %v", err)
+		}
+		for _, s := range stale {
+			log.Printf("  %s", s)
+		}
+		if len(stale) > 0 && !args.update {
+			log.Fatalf("error: specs are stale, update them with `make specs`")
+		}
+		if len(stale) > 0 {
+			log.Printf("📝 updated %d spec(s)", len(stale))
+		}
+		log.Printf("✅  done")
+		return
 	}
 
-	log.Printf("📂 opening input folder %v", args.input)
-	inputFiles, err := os.ReadDir(args.input)
+	if args.llms != "" {
+		if args.check {
+			log.Printf("🔍 checking llms files in %v", args.llms)
+		} else {
+			log.Printf("🤖 generating llms files in %v", args.llms)
+		}
+		stale, err := generateLLMs(args.input, args.llms, args.check)
+		if err != nil {
+			log.Fatalf("error: %v", err)
+		}
+		if len(stale) > 0 {
+			log.Fatalf("error: llms files are stale, regenerate them with `make llms`: %s", strings.Join(stale, ", "))
+		}
+		log.Printf("✅  done")
+		return
+	}
+
+	var (
+		t, ct      *template.Template
+		categories categoryConfig
+	)
+	if args.format == formatMDX {
+		log.Printf("🕵️parsing mdx template")
+		var err error
+		t, err = template.New("").Funcs(genfuncs.FuncMap(args.referenceTime)).Funcs(funcMap).Option("missingkey=zero").Parse(tmpl)
+		if err != nil {
+			log.Fatalf("error: failed to parse mdx template: %v", err)
+		}
+		ct, err = template.New("").Funcs(genfuncs.FuncMap(args.referenceTime)).Funcs(funcMap).Option("missingkey=zero").Parse(categoryTmpl)
+		if err != nil {
+			log.Fatalf("error: failed to parse category template: %v", err)
+		}
+		categories, err = readCategoryConfig(args.categories)
+		if err != nil {
+			log.Fatalf("error: %v", err)
+		}
+	}
+
+	log.Printf("📂 reading specifications in input folder %v", args.input)
+	specs, err := readSpecs(args.input)
 	if err != nil {
-		log.Fatalf("error: failed to open input folder: %v", err)
+		log.Fatalf("error: %v", err)
+	}
+
+	if args.verify {
+		log.Printf("🧪 verifying examples with reference processors")
+		verified, err := verifyExamples(specs)
+		if err != nil {
+			log.Fatalf("error: %v", err)
+		}
+		log.Printf("🧪 verified %d example(s)", verified)
 	}
 
 	if fileInfo, err := os.Stat(args.output); os.IsNotExist(err) {
@@ -47,73 +130,138 @@ func main() {
 		log.Printf("📂 output folder %v already exists, contents may be overwritten", args.output)
 	}
 
-	log.Printf("🏃 walking over files in input folder %v", args.input)
-	index := 0
-	for _, dirEntry := range inputFiles {
-		if dirEntry.IsDir() {
-			// skip directories
-			continue
+	var errs []error
+	switch args.format {
+	case formatMDX:
+		log.Printf("🗂️ grouping processors by category")
+		grouped, err := categories.assign(specs)
+		if err != nil {
+			log.Fatalf("error: %v", err)
 		}
-		if !strings.HasSuffix(dirEntry.Name(), ".json") {
-			// skip non-JSON files
-			continue
+		index, err := readPageIndex(args.pageIndex)
+		if err != nil {
+			log.Fatalf("error: %v", err)
+		}
+		index = index.assignPositions(grouped)
+		for _, c := range grouped {
+			log.Printf("🔄 %v ...", c.ID)
+			log.SetPrefix("  ") // indent
+			errs = append(errs, exportCategory(c, args.output, t, ct)...)
+			log.SetPrefix("") // reset indent
+		}
+		if len(errs) == 0 {
+			log.Printf("💾 saving page index to %v", args.pageIndex)
+			if err := index.write(args.pageIndex); err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		}
+	case formatJSONSchema:
+		log.Printf("🏃 walking over specifications")
+		for _, spec := range specs {
+			log.Printf("🔄 %v ...", spec.File)
+			log.SetPrefix("  ") // indent
+			if err := exportProcessorSchema(spec, args.output); err != nil {
+				log.Printf("❌ %v", err)
+				errs = append(errs, fmt.Errorf("%s: %w", spec.File, err))
+			}
+			log.SetPrefix("") // reset indent
 		}
-
-		log.Printf("🔄 %v ...", dirEntry.Name())
-		log.SetPrefix("  ") // indent
-		exportProcessorDoc(
-			index,
-			filepath.Join(args.input, dirEntry.Name()),
-			args.output,
-			t,
-		)
-		log.SetPrefix("") // reset indent
-		index++
+	}
+	if len(errs) > 0 {
+		log.Fatalf("error: failed to generate %d processor(s):\n%v", len(errs), errors.Join(errs...))
 	}
 
 	log.Printf("✅  done")
 }
 
-func exportProcessorDoc(index int, inputPath, outputPath string, t *template.Template) {
-	log.Printf("🕵️  decoding contents as JSON")
+// exportCategory writes the pages of the processors in the category to a
+// folder named after the category, together with an index page listing the
+// processors and their summaries. Errors of individual processors are
+// returned, so all problems are reported at once.
+func exportCategory(c category, outputPath string, t, ct *template.Template) []error {
+	dir := filepath.Join(outputPath, c.ID)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return []error{fmt.Errorf("failed to create %s: %w", dir, err)}
+	}
 
-	input, err := os.Open(inputPath)
-	if err != nil {
-		log.Fatalf("error: failed to open %s: %v", inputPath, err)
+	log.Printf("📄 generating %s/index.mdx", c.ID)
+	var buf bytes.Buffer
+	if err := ct.Execute(&buf, c); err != nil {
+		return []error{fmt.Errorf("failed to render index of category %s: %w", c.ID, err)}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.mdx"), buf.Bytes(), 0600); err != nil {
+		return []error{fmt.Errorf("failed to write index of category %s: %w", c.ID, err)}
 	}
-	defer input.Close()
 
-	var proc map[string]any
-	err = json.NewDecoder(input).Decode(&proc)
+	// the position of the category in the sidebar is set in _category_.json
+	meta, err := json.MarshalIndent(map[string]any{"label": c.Title, "position": c.Position}, "", "  ")
 	if err != nil {
-		log.Fatalf("error: failed to parse input file as JSON: %v", err)
+		return []error{fmt.Errorf("failed to marshal _category_.json: %w", err)}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "_category_.json"), append(meta, '\n'), 0600); err != nil {
+		return []error{fmt.Errorf("failed to write _category_.json of category %s: %w", c.ID, err)}
 	}
 
-	procName := proc["specification"].(map[string]any)["name"].(string)
-	log.Printf("📄 generating %s.mdx", procName)
+	var errs []error
+	for _, spec := range c.Specs {
+		if err := exportProcessorDoc(c.Positions[spec.Specification.Name], spec, dir, t); err != nil {
+			// continue with the other processors, so all problems are reported at once
+			log.Printf("❌ %v", err)
+			errs = append(errs, fmt.Errorf("%s: %w", spec.File, err))
+		}
+	}
+	return errs
+}
 
-	// inject index into the processor
-	proc["index"] = index
+// processorPage is the data passed to the mdx template.
+type processorPage struct {
+	processorSpec
+	// Index is the position of the page in the sidebar.
+	Index int
+}
 
-	path := filepath.Join(outputPath, procName+".mdx")
+func exportProcessorDoc(index int, spec processorSpec, outputPath string, t *template.Template) error {
+	procName := spec.Specification.Name
+	log.Printf("📄 generating %s.mdx", procName)
 
-	output, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
-	if err != nil {
-		log.Fatalf("error: failed to open %s: %v", path, err)
+	// render into a buffer first, so a failing template doesn't leave a
+	// truncated page behind
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, processorPage{processorSpec: spec, Index: index}); err != nil {
+		return fmt.Errorf("failed to render %s.mdx: %w", procName, err)
 	}
-	defer output.Close()
 
-	err = t.Execute(output, proc)
-	if err != nil {
-		log.Fatalf("error: failed to write %s: %v", path, err)
+	path := filepath.Join(outputPath, procName+".mdx")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
 	}
+	return nil
 }
 
 var funcMap = template.FuncMap{
-	"formatParameterValueTable":      formatParameterValueTable,
-	"formatParameterValueYAML":       formatParameterValueYAML,
-	"formatParameterDescriptionYAML": formatParameterDescriptionYAML,
-	"formatRecord":                   formatRecord,
+	"formatParameterValueTable": formatParameterValueTable,
+	"examplePipeline":           examplePipelineYAML,
+	"exampleConfig":             exampleConfigYAML,
+	"formatParameterType":       formatParameterType,
+	"recordDiffs":               recordDiffs,
+	"recordJSON":                recordJSON,
+	"mdx":                       genfuncs.EscapeMDX,
+	"frontMatter":               genfuncs.FrontMatterString,
+	"processorsURL":             func() string { return processorsURL },
+	"conditionsURL":             func() string { return conditionsURL },
+	"processorSlug":             processorSlug,
+	"tableCell":                 tableCell,
+}
+
+// processorSlug returns the slug of a processor page. Pages are grouped in
+// category folders, the slug keeps the URL independent of the category.
+func processorSlug(name string) string {
+	return strings.TrimPrefix(processorsURL, "/docs") + name
+}
+
+// tableCell formats text, so it fits into a cell of a markdown table.
+func tableCell(s string) string {
+	return strings.ReplaceAll(genfuncs.EscapeMDX(oneLine(s)), "|", "\\|")
 }
 
 // formatParameterValue formats the value of a configuration parameter.
@@ -129,153 +277,47 @@ func formatParameterValueTable(value string) string {
 	}
 }
 
-func formatParameterDescriptionYAML(description string) string {
-	const (
-		indentLen  = 10
-		prefix     = "# "
-		lineLen    = 80
-		tmpNewLine = "〠"
-	)
-
-	// remove markdown new lines
-	description = strings.ReplaceAll(description, "\n\n", tmpNewLine)
-	description = strings.ReplaceAll(description, "\n", " ")
-	description = strings.ReplaceAll(description, tmpNewLine, "\n")
-
-	formattedDescription := formatMultiline(description, strings.Repeat(" ", indentLen)+prefix, lineLen)
-	// remove first indent and last new line
-	formattedDescription = formattedDescription[indentLen : len(formattedDescription)-1]
-	return formattedDescription
-}
-
-func formatMultiline(
-	input string,
-	prefix string,
-	maxLineLen int,
-) string {
-	textLen := maxLineLen - len(prefix)
-
-	// split the input into lines of length textLen
-	lines := strings.Split(input, "\n")
-	var formattedLines []string
-	for _, line := range lines {
-		if len(line) <= textLen {
-			formattedLines = append(formattedLines, line)
-			continue
-		}
-
-		// split the line into multiple lines, don't break words
-		words := strings.Fields(line)
-		var formattedLine string
-		for _, word := range words {
-			if len(formattedLine)+len(word) > textLen {
-				formattedLines = append(formattedLines, formattedLine[1:])
-				formattedLine = ""
-			}
-			formattedLine += " " + word
-		}
-		if formattedLine != "" {
-			formattedLines = append(formattedLines, formattedLine[1:])
-		}
-	}
-
-	// combine lines including indent and prefix
-	var formatted string
-	for _, line := range formattedLines {
-		formatted += prefix + line + "\n"
-	}
-
-	return formatted
-}
-
-func formatParameterValueYAML(value string) string {
-	switch {
-	case value == "":
-		return `""`
-	case strings.Contains(value, "\n"):
-		// specifically used in the javascript processor
-		formattedValue := formatMultiline(value, "            ", 10000)
-		return fmt.Sprintf("|\n%s", formattedValue)
-	default:
-		return fmt.Sprintf(`"%s"`, value)
-	}
-}
-
-// formatRecord formats a record as an escaped string.
-func formatRecord(rec any) (string, error) {
-	var out string
-
-	switch rec := rec.(type) {
-	case map[string]any:
-		switch {
-		case len(rec) == 0:
-			out = ""
-		case rec["error"] != nil:
-			out = mustJSONMarshal(rec)
-		default:
-			// map the record to a struct so we control the order of the fields in JSON
-			out = mustJSONMarshal(mapToRecord(rec))
-		}
-	case []any:
-		// multi-record
-		recs := make([]string, len(rec))
-		for i, r := range rec {
-			recs[i] = mustJSONMarshal(mapToRecord(r.(map[string]any)))
-		}
-		out = strings.Join(recs, "\n")
-	case nil:
-		out = ""
-	default:
-		return "", fmt.Errorf("unexpected record type %T", rec)
-	}
-
-	return strings.Trim(fmt.Sprintf("%#v", out), "\""), nil
-}
-
-func mapToRecord(rec map[string]any) record {
-	return record{
-		Position:  rec["position"],
-		Operation: rec["operation"],
-		Metadata:  rec["metadata"],
-		Key:       rec["key"],
-		Payload: struct {
-			Before any `json:"before"`
-			After  any `json:"after"`
-		}{
-			Before: rec["payload"].(map[string]any)["before"],
-			After:  rec["payload"].(map[string]any)["after"],
-		},
-	}
-}
-
-func mustJSONMarshal(v any) string {
-	b, err := json.MarshalIndent(v, "", "  ")
-	if err != nil {
-		panic(fmt.Errorf("failed to marshal value: %w", err))
-	}
-	return string(b)
-}
-
-type record struct {
-	Position  any `json:"position"`
-	Operation any `json:"operation"`
-	Metadata  any `json:"metadata"`
-	Key       any `json:"key"`
-	Payload   struct {
-		Before any `json:"before"`
-		After  any `json:"after"`
-	} `json:"payload"`
-}
+// Output formats of the generated files.
+const (
+	formatMDX        = "mdx"
+	formatJSONSchema = "jsonschema"
+)
 
 type Args struct {
 	output string
+	format string
 	input  string
+	lint   bool
+	llms   string
+	check  bool
+
+	conduit        string
+	processorsPath string
+	update         bool
+
+	categories    string
+	pageIndex     string
+	verify        bool
+	referenceTime time.Time
 }
 
 func parseFlags() Args {
 	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 	var (
 		output = flags.String("output", "docs", "path to the output folder")
+		format = flags.String("format", formatMDX, "format of the generated files, mdx (documentation pages) or jsonschema (JSON Schema of the processor settings)")
+		lint   = flags.Bool("lint", false, "check the generated pages in the output folder for MDX syntax errors instead of generating them")
+		llms   = flags.String("llms", "", "path to the folder where processors.txt and processors-full.txt are written instead of generating pages")
+		check  = flags.Bool("check", false, "fail if the llms files are stale instead of writing them (requires -llms)")
+
+		conduit        = flags.String("conduit", "", "path to a Conduit binary or checkout, fail if the specs in the input folder differ from its processor specifications instead of generating pages")
+		processorsPath = flags.String("processors-path", "", "path to the standalone (WASM) processors loaded by the Conduit binary (requires -conduit)")
+		update         = flags.Bool("update", false, "write the processor specifications from Conduit to the input folder instead of failing if they differ (requires -conduit)")
+
+		categories = flags.String("categories", "categories.yaml", "path to the category config, processor pages are grouped in a folder per category")
+		pageIndex  = flags.String("page-index", "processor-pages.json", "path to the file recording the sidebar positions of the processor pages")
+		verify     = flags.Bool("verify", false, "run the examples of deterministic processors through a reference implementation and fail if the output differs from want")
+		refTime    = flags.String("reference-time", "", "time relative dates in the templates are based on (RFC 3339), e.g. the time of the last commit changing the specs")
 	)
 
 	logAndExit := func(msg string) {
@@ -290,12 +332,40 @@ func parseFlags() Args {
 
 	args := Args{
 		output: *output,
+		format: *format,
 		input:  "specs",
+		lint:   *lint,
+		llms:   *llms,
+		check:  *check,
+
+		conduit:        *conduit,
+		processorsPath: *processorsPath,
+		update:         *update,
+
+		categories: *categories,
+		pageIndex:  *pageIndex,
+		verify:     *verify,
+	}
+	if *refTime != "" {
+		t, err := time.Parse(time.RFC3339, *refTime)
+		if err != nil {
+			logAndExit(fmt.Sprintf("invalid -reference-time: %v", err))
+		}
+		args.referenceTime = t
 	}
 
 	if args.output == "" {
 		logAndExit("output path argument cannot be empty")
 	}
+	if args.format != formatMDX && args.format != formatJSONSchema {
+		logAndExit(fmt.Sprintf("unknown format %q, supported formats: %s, %s", args.format, formatMDX, formatJSONSchema))
+	}
+	if args.check && args.llms == "" {
+		logAndExit("-check requires the -llms flag")
+	}
+	if (args.processorsPath != "" || args.update) && args.conduit == "" {
+		logAndExit("-processors-path and -update require the -conduit flag")
+	}
 	if len(flags.Args()) > 0 {
 		args.input = flags.Args()[0]
 	}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template/parse"
+)
+
+// Pages explaining the values of reference and template parameters.
+const (
+	referencingFieldsURL = "/docs/using/processors/referencing-fields"
+	conditionsURL        = "/docs/using/processors/conditions"
+)
+
+// parameterKind describes what the string value of a parameter contains,
+// beyond its type.
+type parameterKind string
+
+const (
+	// kindPlain is a value without special syntax.
+	kindPlain parameterKind = ""
+	// kindReference is a field reference, e.g. .Payload.After.foo.
+	kindReference parameterKind = "reference"
+	// kindReferenceList is a comma separated list of field references.
+	kindReferenceList parameterKind = "reference list"
+	// kindReferenceMapping is a comma separated list of field references and
+	// new names separated by colons, e.g. .Metadata.key:id.
+	kindReferenceMapping parameterKind = "reference mapping"
+	// kindTemplate is a Go template executed on the record.
+	kindTemplate parameterKind = "template"
+)
+
+// parameterKindOverrides sets the kind of parameters which can't be
+// recognized by their name or description. Keys are processor names.
+var parameterKindOverrides = map[string]map[string]parameterKind{
+	"cohere.command": {"request.body": kindReference, "response.body": kindReference},
+	"cohere.rerank":  {"request.body": kindReference, "response.body": kindReference},
+	"field.rename":   {"mapping": kindReferenceMapping},
+}
+
+var (
+	// referencingFieldsLink matches the link to the referencing fields page,
+	// which Conduit adds to the description of reference parameters.
+	referencingFieldsLink = regexp.MustCompile(`\[Referencing fields\]\([^)]*referencing-fields\)`)
+	// goTemplate matches descriptions of template parameters, e.g. "Go
+	// template expression" or "Go [templates](...)".
+	goTemplate = regexp.MustCompile(`Go \[?templates?\b`)
+)
+
+// kindOf returns the kind of a string parameter. The kind is taken from
+// parameterKindOverrides, the parameter name (field, fields, *Field and
+// condition) or the description (a link to the referencing fields page or a
+// mention of Go templates), in that order.
+func kindOf(processor, name string, p parameter) parameterKind {
+	if p.Type != "string" {
+		return kindPlain
+	}
+	if kind, ok := parameterKindOverrides[processor][name]; ok {
+		return kind
+	}
+	switch {
+	case name == "fields":
+		return kindReferenceList
+	case name == "field" || strings.HasSuffix(name, "Field"):
+		return kindReference
+	case name == "condition":
+		return kindTemplate
+	case referencingFieldsLink.MatchString(p.Description):
+		return kindReference
+	case goTemplate.MatchString(p.Description):
+		return kindTemplate
+	}
+	return kindPlain
+}
+
+// formatParameterType formats the type of a parameter for the parameter
+// table, reference and template parameters link to the page explaining their
+// syntax.
+func formatParameterType(processor, name string, p parameter) string {
+	switch kindOf(processor, name, p) {
+	case kindReference:
+		return fmt.Sprintf("%s ([field reference](%s))", p.Type, referencingFieldsURL)
+	case kindReferenceList:
+		return fmt.Sprintf("%s (list of [field references](%s))", p.Type, referencingFieldsURL)
+	case kindReferenceMapping:
+		return fmt.Sprintf("%s ([field references](%s) and names)", p.Type, referencingFieldsURL)
+	case kindTemplate:
+		return fmt.Sprintf("%s ([Go template](%s))", p.Type, conditionsURL)
+	}
+	return p.Type
+}
+
+// checkParameterSyntax checks that a non-empty value is valid for the kind of
+// the parameter, e.g. that a reference can be parsed.
+func checkParameterSyntax(kind parameterKind, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch kind {
+	case kindReference:
+		_, err := parseReference(value)
+		return err
+	case kindReferenceList:
+		for _, ref := range strings.Split(value, ",") {
+			if _, err := parseReference(ref); err != nil {
+				return err
+			}
+		}
+	case kindReferenceMapping:
+		for _, pair := range strings.Split(value, ",") {
+			ref, name, ok := strings.Cut(pair, ":")
+			if !ok || strings.TrimSpace(name) == "" {
+				return fmt.Errorf("invalid mapping %q: expected reference:name", pair)
+			}
+			if _, err := parseReference(ref); err != nil {
+				return err
+			}
+		}
+	case kindTemplate:
+		// functions are provided by Conduit (e.g. sprig), only the syntax is
+		// checked
+		t := parse.New("value")
+		t.Mode = parse.SkipFuncCheck
+		if _, err := t.Parse(value, "", "", make(map[string]*parse.Tree)); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	}
+	return nil
+}
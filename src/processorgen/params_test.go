@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+func TestCheckParameterSyntax(t *testing.T) {
+	tests := []struct {
+		kind    parameterKind
+		value   string
+		wantErr bool
+	}{
+		{kind: kindPlain, value: "{{ anything"},
+		{kind: kindReference, value: ""},
+		{kind: kindReference, value: ".Payload.After.foo"},
+		{kind: kindReference, value: "Payload.After", wantErr: true},
+		{kind: kindReferenceList, value: ".Metadata.foo,.Key"},
+		{kind: kindReferenceList, value: ".Metadata.foo,.Payload.foo", wantErr: true},
+		{kind: kindReferenceMapping, value: ".Metadata.foo:bar,.Payload.After.a:b"},
+		{kind: kindReferenceMapping, value: ".Metadata.foo", wantErr: true},
+		{kind: kindReferenceMapping, value: ".Metadata.foo: ", wantErr: true},
+		{kind: kindReferenceMapping, value: "foo:bar", wantErr: true},
+		{kind: kindTemplate, value: `{{ eq .Metadata.foo "bar" }}`},
+		// functions are provided by Conduit and not checked
+		{kind: kindTemplate, value: `{{ hasPrefix "a" .Metadata.foo }}`},
+		{kind: kindTemplate, value: "{{ .Metadata.foo ", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := checkParameterSyntax(tt.kind, tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkParameterSyntax(%q, %q): expected error: %v, got %v", tt.kind, tt.value, tt.wantErr, err)
+		}
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		processor string
+		name      string
+		param     parameter
+		want      parameterKind
+	}{
+		{processor: "field.set", name: "field", param: parameter{Type: "string"}, want: kindReference},
+		{processor: "field.exclude", name: "fields", param: parameter{Type: "string"}, want: kindReferenceList},
+		{processor: "field.rename", name: "mapping", param: parameter{Type: "string"}, want: kindReferenceMapping},
+		{processor: "json.decode", name: "outputField", param: parameter{Type: "string"}, want: kindReference},
+		{processor: "filter", name: "condition", param: parameter{Type: "string"}, want: kindTemplate},
+		{processor: "cohere.command", name: "request.body", param: parameter{Type: "string"}, want: kindReference},
+		{processor: "webhook.http", name: "request.url", param: parameter{Type: "string", Description: "URL, supports Go templates"}, want: kindTemplate},
+		{processor: "custom", name: "source", param: parameter{Type: "string", Description: "See [Referencing fields](https://conduit.io/docs/using/processors/referencing-fields)."}, want: kindReference},
+		{processor: "field.set", name: "value", param: parameter{Type: "string"}, want: kindPlain},
+		{processor: "split", name: "field", param: parameter{Type: "int"}, want: kindPlain},
+	}
+	for _, tt := range tests {
+		if got := kindOf(tt.processor, tt.name, tt.param); got != tt.want {
+			t.Errorf("kindOf(%q, %q): expected %q, got %q", tt.processor, tt.name, tt.want, got)
+		}
+	}
+}
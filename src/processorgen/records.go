@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// recordDiff is a single difference between the input record of an example
+// and one of the records the processor returned, as it is rendered in the
+// page.
+type recordDiff struct {
+	// Title is set if the processor returns multiple records.
+	Title string
+	// Before and After are the records formatted for display and escaped, so
+	// they can be used in a JavaScript string.
+	Before string
+	After  string
+	// Changes are the paths of the fields that differ, e.g.
+	// .Payload.After.foo.
+	Changes []string
+	// Filtered is true if the processor didn't return the record.
+	Filtered bool
+}
+
+// recordDiffs returns the differences between the input record of an example
+// and the records the processor returns. Each returned record gets its own
+// diff. Examples that return an error have no diffs, the error is rendered
+// separately.
+func recordDiffs(have *exampleRecord, want exampleOutput) ([]recordDiff, error) {
+	if want.Error != "" {
+		return nil, nil
+	}
+
+	before, err := escapedRecordJSON(have)
+	if err != nil {
+		return nil, err
+	}
+	if len(want.Records) == 0 {
+		return []recordDiff{{Before: before, Filtered: true}}, nil
+	}
+
+	diffs := make([]recordDiff, len(want.Records))
+	for i, rec := range want.Records {
+		after, err := escapedRecordJSON(&rec)
+		if err != nil {
+			return nil, err
+		}
+		diffs[i] = recordDiff{
+			Before:  before,
+			After:   after,
+			Changes: changedFields(have, &rec),
+		}
+		if want.Multiple {
+			diffs[i].Title = fmt.Sprintf("Record %d of %d", i+1, len(want.Records))
+		}
+	}
+	return diffs, nil
+}
+
+// recordJSON formats a record as indented JSON for display. Raw data is
+// decoded, see displayRecord.
+func recordJSON(rec *exampleRecord) (string, error) {
+	if rec == nil {
+		return "", nil
+	}
+	b, err := json.MarshalIndent(displayRecord(*rec), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal record: %w", err)
+	}
+	return string(b), nil
+}
+
+// escapedRecordJSON formats a record like recordJSON and escapes it, so it can
+// be used in a JavaScript string.
+func escapedRecordJSON(rec *exampleRecord) (string, error) {
+	out, err := recordJSON(rec)
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(fmt.Sprintf("%#v", out), "\""), nil
+}
+
+// displayRecord returns a copy of the record, in which the bytes of the record
+// are decoded for display. The position is base64 encoded in the
+// specifications and decoded if it's text. Raw keys and payloads which aren't
+// text are shown as hex bytes. Timestamps in opencdc.* metadata fields are
+// shown with the time they represent.
+func displayRecord(rec exampleRecord) exampleRecord {
+	if s, ok := rec.Position.(string); ok {
+		if b, err := base64.StdEncoding.DecodeString(s); err == nil && isText(b) {
+			rec.Position = string(b)
+		}
+	}
+	rec.Key = displayRawData(rec.Key)
+	rec.Payload.Before = displayRawData(rec.Payload.Before)
+	rec.Payload.After = displayRawData(rec.Payload.After)
+
+	if md, ok := rec.Metadata.(map[string]any); ok {
+		display := make(map[string]any, len(md))
+		for k, v := range md {
+			display[k] = displayMetadata(k, v)
+		}
+		rec.Metadata = display
+	}
+	return rec
+}
+
+// displayRawData shows raw data which isn't text as hex bytes. Structured
+// data is returned as is.
+func displayRawData(v any) any {
+	s, ok := v.(string)
+	if !ok || isText([]byte(s)) {
+		return v
+	}
+	return fmt.Sprintf("bytes(% x)", []byte(s))
+}
+
+// opencdcTimestamps are the metadata fields containing a unix timestamp in
+// nanoseconds, see https://conduit.io/docs/using/opencdc-record#metadata.
+var opencdcTimestamps = []string{"opencdc.createdAt", "opencdc.readAt"}
+
+// displayMetadata adds the time to opencdc.* timestamps, other metadata
+// values are returned as is.
+func displayMetadata(key string, v any) any {
+	s, ok := v.(string)
+	if !ok || !slices.Contains(opencdcTimestamps, key) {
+		return v
+	}
+	nanos, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return v
+	}
+	return fmt.Sprintf("%s (%s)", s, time.Unix(0, nanos).UTC().Format(time.RFC3339Nano))
+}
+
+// isText returns true if b is valid UTF-8 without control characters, other
+// than whitespace.
+func isText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// changedFields returns the paths of the fields that differ between the
+// records, sorted by their position in the record. Structured data is
+// compared field by field, so only the fields that changed are reported.
+func changedFields(have, want *exampleRecord) []string {
+	var a, b exampleRecord
+	if have != nil {
+		a = *have
+	}
+	if want != nil {
+		b = *want
+	}
+
+	var changes []string
+	if !reflect.DeepEqual(a.Position, b.Position) {
+		changes = append(changes, ".Position")
+	}
+	if a.Operation != b.Operation {
+		changes = append(changes, ".Operation")
+	}
+	// missing metadata is the same as empty metadata, so added fields are
+	// reported individually
+	changes = append(changes, changedValues(".Metadata", metadataMap(a.Metadata), metadataMap(b.Metadata))...)
+	changes = append(changes, changedValues(".Key", a.Key, b.Key)...)
+	changes = append(changes, changedValues(".Payload.Before", a.Payload.Before, b.Payload.Before)...)
+	changes = append(changes, changedValues(".Payload.After", a.Payload.After, b.Payload.After)...)
+	return changes
+}
+
+func metadataMap(md any) any {
+	if md == nil {
+		return map[string]any{}
+	}
+	return md
+}
+
+// changedValues compares two decoded JSON values and returns the paths of the
+// values that differ. Maps are compared key by key, all other values are
+// compared as a whole.
+func changedValues(path string, a, b any) []string {
+	ma, okA := a.(map[string]any)
+	mb, okB := b.(map[string]any)
+	if !okA || !okB {
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+		return []string{path}
+	}
+
+	union := maps.Clone(ma)
+	maps.Copy(union, mb)
+	keys := slices.Sorted(maps.Keys(union))
+
+	var changes []string
+	for _, k := range keys {
+		va, inA := ma[k]
+		vb, inB := mb[k]
+		if inA != inB {
+			// added or removed
+			changes = append(changes, fieldPath(path, k))
+			continue
+		}
+		changes = append(changes, changedValues(fieldPath(path, k), va, vb)...)
+	}
+	return changes
+}
+
+// identifier matches keys that can be used in a field reference without
+// brackets.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// fieldPath appends the key to the path, using the field reference syntax of
+// Conduit (e.g. .Payload.After.foo or .Metadata["opencdc.readAt"]).
+func fieldPath(path, key string) string {
+	if identifier.MatchString(key) {
+		return path + "." + key
+	}
+	return path + "[" + strconv.Quote(key) + "]"
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedFields(t *testing.T) {
+	base := func() *exampleRecord {
+		return &exampleRecord{
+			Position:  "cG9z",
+			Operation: "create",
+			Metadata:  map[string]any{"opencdc.readAt": "1", "foo": "bar"},
+			Key:       "id-1",
+			Payload: examplePayload{
+				After: map[string]any{"name": "Alice", "address": map[string]any{"city": "Berlin"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		change func(r *exampleRecord)
+		want   []string
+	}{{
+		name:   "unchanged",
+		change: func(*exampleRecord) {},
+	}, {
+		name: "position and operation",
+		change: func(r *exampleRecord) {
+			r.Position = "b3RoZXI="
+			r.Operation = "update"
+		},
+		want: []string{".Position", ".Operation"},
+	}, {
+		name: "metadata",
+		change: func(r *exampleRecord) {
+			r.Metadata = map[string]any{"opencdc.readAt": "2", "new": "x"}
+		},
+		want: []string{".Metadata.foo", ".Metadata.new", `.Metadata["opencdc.readAt"]`},
+	}, {
+		name:   "missing metadata",
+		change: func(r *exampleRecord) { r.Metadata = nil },
+		want:   []string{".Metadata.foo", `.Metadata["opencdc.readAt"]`},
+	}, {
+		name: "nested payload field",
+		change: func(r *exampleRecord) {
+			r.Payload.After = map[string]any{"name": "Alice", "address": map[string]any{"city": "Paris"}}
+		},
+		want: []string{".Payload.After.address.city"},
+	}, {
+		name: "raw and structured data",
+		change: func(r *exampleRecord) {
+			r.Key = map[string]any{"id": "1"}
+			r.Payload.Before = "raw"
+		},
+		want: []string{".Key", ".Payload.Before"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := base()
+			tt.change(want)
+			if got := changedFields(base(), want); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	// missing metadata is the same as empty metadata
+	if got := changedFields(&exampleRecord{}, &exampleRecord{Metadata: map[string]any{}}); got != nil {
+		t.Errorf("expected no changes, got %q", got)
+	}
+}
+
+func TestFieldPath(t *testing.T) {
+	tests := map[string]string{
+		"foo":           ".Payload.After.foo",
+		"_id2":          ".Payload.After._id2",
+		"foo.bar":       `.Payload.After["foo.bar"]`,
+		"2nd":           `.Payload.After["2nd"]`,
+		`with "quotes"`: `.Payload.After["with \"quotes\""]`,
+	}
+	for key, want := range tests {
+		if got := fieldPath(".Payload.After", key); got != want {
+			t.Errorf("fieldPath(%q): expected %q, got %q", key, want, got)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/conduitio/genfuncs"
+)
+
+// schemasURL is the URL under which the processor schemas are published.
+const schemasURL = siteURL + "/schemas/processors/"
+
+// exportProcessorSchema writes the JSON Schema of the processor settings to
+// <name>.schema.json in outputPath.
+func exportProcessorSchema(proc processorSpec, outputPath string) error {
+	spec := proc.Specification
+	log.Printf("📄 generating %s.schema.json", spec.Name)
+
+	// sort parameters, so the output is deterministic
+	var params []genfuncs.Parameter
+	for _, name := range slices.Sorted(maps.Keys(spec.Parameters)) {
+		p := spec.Parameters[name]
+		param := genfuncs.Parameter{
+			Name:        name,
+			Type:        p.Type,
+			Default:     p.Default,
+			Description: strings.ReplaceAll(p.Description, "conduit.io", "conduitdata.io"),
+		}
+		for _, v := range p.Validations {
+			param.Validations = append(param.Validations, genfuncs.Validation{Type: v.Type, Value: v.Value})
+		}
+		params = append(params, param)
+	}
+
+	file := spec.Name + ".schema.json"
+	schema, err := genfuncs.SettingsSchema(schemasURL+file, spec.Name, spec.Summary, params)
+	if err != nil {
+		return fmt.Errorf("failed to create schema of %s: %w", spec.Name, err)
+	}
+
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	path := filepath.Join(outputPath, file)
+	if err := os.WriteFile(path, append(out, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
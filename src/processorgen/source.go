@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// checkoutSpecsPath is the folder in a Conduit checkout containing the
+	// specifications of the builtin processors, including their examples.
+	checkoutSpecsPath = "pkg/plugin/processor/builtin/internal/exampleutil/specs"
+
+	// conduitStartTimeout is how long we wait for the API of the Conduit
+	// binary to become available.
+	conduitStartTimeout = 30 * time.Second
+)
+
+// specFiles maps the name of a specification file (e.g. field.set.json) to
+// its contents.
+type specFiles map[string][]byte
+
+// readSpecFiles reads all JSON files in folder. A missing folder is treated as
+// an empty one, so the specs can be fetched into a new folder.
+func readSpecFiles(folder string) (specFiles, error) {
+	entries, err := os.ReadDir(folder)
+	if errors.Is(err, os.ErrNotExist) {
+		return specFiles{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", folder, err)
+	}
+
+	files := make(specFiles)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(folder, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		files[entry.Name()] = raw
+	}
+	return files, nil
+}
+
+// checkoutSpecs returns the specifications of the builtin processors in a
+// local checkout of the Conduit repository.
+func checkoutSpecs(checkout string) (specFiles, error) {
+	folder := filepath.Join(checkout, checkoutSpecsPath)
+	if _, err := os.Stat(folder); err != nil {
+		return nil, fmt.Errorf("%s is not a Conduit checkout: %w", checkout, err)
+	}
+	files, err := readSpecFiles(folder)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no specifications found in %s", folder)
+	}
+	return files, nil
+}
+
+// conduitSpecs starts the Conduit binary with an in-memory database and
+// returns the specifications of all processor plugins it reports, including
+// the standalone (WASM) processors found in processorsPath. The API doesn't
+// return examples, they are taken over from the current specification files
+// and therefore must not be compared by staleSpecs.
+func conduitSpecs(ctx context.Context, binary, processorsPath string, current specFiles) (specFiles, error) {
+	plugins, err := fetchProcessorPlugins(ctx, binary, processorsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(specFiles)
+	for _, plugin := range plugins {
+		spec := pluginSpecification(plugin)
+		if spec.Name == "" {
+			return nil, fmt.Errorf("processor plugin %v has no name", plugin["name"])
+		}
+		file := spec.Name + ".json"
+
+		// examples are copied as they are, so they keep their formatting
+		var existing struct {
+			Examples []json.RawMessage `json:"examples"`
+		}
+		if raw, ok := current[file]; ok {
+			if err := json.Unmarshal(raw, &existing); err != nil {
+				return nil, fmt.Errorf("failed to parse %s as JSON: %w", file, err)
+			}
+		}
+		if existing.Examples == nil {
+			existing.Examples = []json.RawMessage{}
+		}
+
+		raw, err := marshalSpec(struct {
+			Specification specification     `json:"specification"`
+			Examples      []json.RawMessage `json:"examples"`
+		}{
+			Specification: spec,
+			Examples:      existing.Examples,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", file, err)
+		}
+		files[file] = raw
+	}
+	return files, nil
+}
+
+// fetchProcessorPlugins runs `conduit run` in a temporary directory and lists
+// the processor plugins using the HTTP API. Conduit is stopped before
+// returning.
+func fetchProcessorPlugins(ctx context.Context, binary, processorsPath string) ([]map[string]any, error) {
+	dir, err := os.MkdirTemp("", "processorgen")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if processorsPath == "" {
+		processorsPath = filepath.Join(dir, "processors")
+	}
+	httpAddress, err := freeAddress()
+	if err != nil {
+		return nil, err
+	}
+	grpcAddress, err := freeAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, "run",
+		"--config.path="+filepath.Join(dir, "conduit.yaml"),
+		"--db.type=inmemory",
+		"--api.http.address="+httpAddress,
+		"--api.grpc.address="+grpcAddress,
+		"--pipelines.path="+filepath.Join(dir, "pipelines"),
+		"--connectors.path="+filepath.Join(dir, "connectors"),
+		"--processors.path="+processorsPath,
+		"--log.level=warn",
+	)
+	cmd.Dir = dir
+	cmd.Stdout = io.Discard
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", binary, err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	defer func() {
+		cancel()
+		<-exited
+	}()
+
+	url := "http://" + httpAddress + "/v1/processors/plugins"
+	deadline := time.Now().Add(conduitStartTimeout)
+	for {
+		plugins, err := getProcessorPlugins(ctx, url)
+		if err == nil {
+			return plugins, nil
+		}
+		select {
+		case err := <-exited:
+			exited <- err // the deferred cleanup waits for it as well
+			return nil, fmt.Errorf("%s exited before its API was available: %v\n%s", binary, err, stderr.String())
+		case <-time.After(200 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to list processor plugins from %s: %w", binary, err)
+		}
+	}
+}
+
+// getProcessorPlugins requests the list of processor plugins from the HTTP API.
+func getProcessorPlugins(ctx context.Context, url string) ([]map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var plugins []map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&plugins); err != nil {
+		return nil, fmt.Errorf("failed to decode processor plugins: %w", err)
+	}
+	return plugins, nil
+}
+
+// freeAddress returns a local address with a port that is currently unused.
+func freeAddress() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().String(), nil
+}
+
+// pluginSpecification converts a processor plugin returned by the API into the
+// specification format of the specs folder. The plugin type prefix and version
+// suffix are removed from the name (builtin:field.set@v0.1.0 becomes
+// field.set), enum values are converted to the names used in the specs
+// (TYPE_GREATER_THAN becomes greater-than).
+func pluginSpecification(plugin map[string]any) specification {
+	name := stringValue(plugin["name"])
+	if _, after, ok := strings.Cut(name, ":"); ok {
+		name = after
+	}
+	name, _, _ = strings.Cut(name, "@")
+
+	spec := specification{
+		Name:        name,
+		Summary:     stringValue(plugin["summary"]),
+		Description: stringValue(plugin["description"]),
+		Version:     stringValue(plugin["version"]),
+		Author:      stringValue(plugin["author"]),
+		Parameters:  make(map[string]parameter),
+	}
+	params, _ := plugin["parameters"].(map[string]any)
+	for key, p := range params {
+		p, _ := p.(map[string]any)
+		param := parameter{
+			Default:     stringValue(p["default"]),
+			Description: stringValue(p["description"]),
+			Type:        enumName(p["type"]),
+			// the specs contain an empty list, not null
+			Validations: []validation{},
+		}
+		validations, _ := p["validations"].([]any)
+		for _, v := range validations {
+			v, _ := v.(map[string]any)
+			param.Validations = append(param.Validations, validation{
+				Type:  enumName(v["type"]),
+				Value: stringValue(v["value"]),
+			})
+		}
+		spec.Parameters[key] = param
+	}
+	return spec
+}
+
+func enumName(v any) string {
+	s := strings.TrimPrefix(stringValue(v), "TYPE_")
+	return strings.ReplaceAll(strings.ToLower(s), "_", "-")
+}
+
+func stringValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// marshalSpec encodes a specification file the same way Conduit writes them.
+func marshalSpec(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// staleSpecs compares the specification files in the specs folder with the
+// ones from the source and describes every difference. Files are compared by
+// their decoded contents, so formatting differences are ignored and an empty
+// list equals null (e.g. "validations": []). Examples are only compared if
+// compareExamples is true, a Conduit binary doesn't return them.
+func staleSpecs(current, source specFiles, compareExamples bool) ([]string, error) {
+	var stale []string
+	for _, file := range slices.Sorted(maps.Keys(source)) {
+		raw, ok := current[file]
+		if !ok {
+			stale = append(stale, file+" is missing")
+			continue
+		}
+		got, err := comparableSpec(raw, compareExamples)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s as JSON: %w", file, err)
+		}
+		want, err := comparableSpec(source[file], compareExamples)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s from source as JSON: %w", file, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			stale = append(stale, file+" is outdated")
+		}
+	}
+	for _, file := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := source[file]; !ok {
+			stale = append(stale, file+" no longer exists")
+		}
+	}
+	return stale, nil
+}
+
+// comparableSpec decodes a specification file for staleSpecs. Empty lists are
+// replaced with nil and the examples are removed, unless compareExamples is
+// true.
+func comparableSpec(raw []byte, compareExamples bool) (any, error) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	if file, ok := v.(map[string]any); ok && !compareExamples {
+		delete(file, "examples")
+	}
+	return withoutEmptyLists(v), nil
+}
+
+func withoutEmptyLists(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = withoutEmptyLists(value)
+		}
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		for i, value := range v {
+			v[i] = withoutEmptyLists(value)
+		}
+	}
+	return v
+}
+
+// syncSpecs compares the specs folder with the specifications from conduit,
+// which is either the path to a Conduit binary or to a checkout of the Conduit
+// repository, and returns the differences. Standalone processors are loaded
+// from processorsPath when using a binary. If update is true, the folder is
+// additionally replaced with the specifications from conduit.
+func syncSpecs(ctx context.Context, folder, conduit, processorsPath string, update bool) ([]string, error) {
+	current, err := readSpecFiles(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(conduit)
+	if errors.Is(err, os.ErrNotExist) && !strings.ContainsRune(conduit, filepath.Separator) {
+		// the binary can be referenced by name if it's in PATH
+		if path, lookErr := exec.LookPath(conduit); lookErr == nil {
+			conduit = path
+			info, err = os.Stat(conduit)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", conduit, err)
+	}
+	var source specFiles
+	// a checkout contains the examples, the binary doesn't
+	compareExamples := info.IsDir()
+	if compareExamples {
+		source, err = checkoutSpecs(conduit)
+	} else {
+		source, err = conduitSpecs(ctx, conduit, processorsPath, current)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// don't write specifications the generator can't use
+	var errs []error
+	for _, file := range slices.Sorted(maps.Keys(source)) {
+		_, problems := parseSpec(file, source[file])
+		for _, p := range logWarnings(problems) {
+			errs = append(errs, p)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("found %d problem(s) in specifications from %s:\n%w", len(errs), conduit, errors.Join(errs...))
+	}
+
+	stale, err := staleSpecs(current, source, compareExamples)
+	if err != nil || !update {
+		return stale, err
+	}
+
+	if err := os.MkdirAll(folder, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", folder, err)
+	}
+	for file := range current {
+		if _, ok := source[file]; !ok {
+			if err := os.Remove(filepath.Join(folder, file)); err != nil {
+				return nil, fmt.Errorf("failed to remove %s: %w", file, err)
+			}
+		}
+	}
+	for file, raw := range source {
+		if err := os.WriteFile(filepath.Join(folder, file), raw, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return stale, nil
+}
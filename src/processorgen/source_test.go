@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeConduitEnv makes the test binary act as a Conduit binary serving
+// fakeConduitPlugins, see TestMain.
+const fakeConduitEnv = "PROCESSORGEN_FAKE_CONDUIT"
+
+const fakeConduitPlugins = `[{
+  "name": "builtin:field.set@v0.1.0",
+  "summary": "Set the value of a certain field.",
+  "description": "Set the value of a field.",
+  "version": "v0.1.0",
+  "author": "Meroxa, Inc.",
+  "parameters": {
+    "field": {
+      "default": "",
+      "description": "Field is the target field.",
+      "type": "TYPE_STRING",
+      "validations": [{"type": "TYPE_REQUIRED", "value": ""}]
+    }
+  }
+}]`
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(fakeConduitEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "serve":
+		fakeConduit()
+	default:
+		fmt.Fprintln(os.Stderr, "failed to start")
+		os.Exit(1)
+	}
+}
+
+// fakeConduit serves the processor plugins endpoint on the address passed in
+// --api.http.address, like `conduit run` does.
+func fakeConduit() {
+	var address string
+	for _, arg := range os.Args[1:] {
+		if v, ok := strings.CutPrefix(arg, "--api.http.address="); ok {
+			address = v
+		}
+	}
+	http.HandleFunc("/v1/processors/plugins", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(fakeConduitPlugins))
+	})
+	fmt.Fprintln(os.Stderr, http.ListenAndServe(address, nil))
+	os.Exit(1)
+}
+
+func TestStaleSpecs(t *testing.T) {
+	tests := []struct {
+		name            string
+		current         specFiles
+		source          specFiles
+		compareExamples bool
+		want            []string
+		wantErr         bool
+	}{{
+		name: "differences",
+		current: specFiles{
+			"field.set.json":   []byte(`{"specification": {"name": "field.set", "version": "v0.1.0"}}`),
+			"filter.json":      []byte(`{"specification": {"name": "filter", "version": "v0.1.0"}}`),
+			"json.decode.json": []byte(`{"specification":{"name":"json.decode"}}`),
+			"removed.json":     []byte(`{}`),
+		},
+		source: specFiles{
+			// same contents, different order
+			"field.set.json": []byte(`{"specification":{"version":"v0.1.0","name":"field.set"}}`),
+			"filter.json":    []byte(`{"specification": {"name": "filter", "version": "v0.2.0"}}`),
+			// formatting differs, contents are the same
+			"json.decode.json": []byte("{\n  \"specification\": {\n    \"name\": \"json.decode\"\n  }\n}\n"),
+			"split.json":       []byte(`{}`),
+		},
+		want: []string{
+			"filter.json is outdated",
+			"split.json is missing",
+			"removed.json no longer exists",
+		},
+	}, {
+		name:    "empty list equals null",
+		current: specFiles{"split.json": []byte(`{"specification":{"parameters":{"field":{"validations":[]}}}}`)},
+		source:  specFiles{"split.json": []byte(`{"specification":{"parameters":{"field":{"validations":null}}}}`)},
+	}, {
+		name:    "examples not compared",
+		current: specFiles{"split.json": []byte(`{"specification":{},"examples":[{"summary":"Split"}]}`)},
+		source:  specFiles{"split.json": []byte(`{"specification":{},"examples":[]}`)},
+	}, {
+		name:            "examples compared",
+		current:         specFiles{"split.json": []byte(`{"specification":{},"examples":[{"summary":"Split"}]}`)},
+		source:          specFiles{"split.json": []byte(`{"specification":{},"examples":[{"summary":"Split records"}]}`)},
+		compareExamples: true,
+		want:            []string{"split.json is outdated"},
+	}, {
+		name:    "invalid JSON",
+		current: specFiles{"split.json": []byte(`{`)},
+		source:  specFiles{"split.json": []byte(`{}`)},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := staleSpecs(tt.current, tt.source, tt.compareExamples)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckoutSpecs(t *testing.T) {
+	testCases := []struct {
+		name    string
+		files   map[string]string
+		want    specFiles
+		wantErr string
+	}{{
+		name: "specifications",
+		files: map[string]string{
+			checkoutSpecsPath + "/field.set.json": `{"specification":{"name":"field.set"}}`,
+			checkoutSpecsPath + "/README.md":      "not a specification",
+		},
+		want: specFiles{"field.set.json": []byte(`{"specification":{"name":"field.set"}}`)},
+	}, {
+		name:    "not a checkout",
+		files:   map[string]string{"README.md": "conduit"},
+		wantErr: "is not a Conduit checkout",
+	}, {
+		name:    "no specifications",
+		files:   map[string]string{checkoutSpecsPath + "/README.md": "no specs yet"},
+		wantErr: "no specifications found",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for file, content := range tc.files {
+				path := filepath.Join(dir, filepath.FromSlash(file))
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := checkoutSpecs(dir)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestConduitSpecs(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mode    string
+		current specFiles
+		want    string
+		wantErr string
+	}{{
+		name: "new processor",
+		mode: "serve",
+		want: `{
+  "specification": {
+    "name": "field.set",
+    "summary": "Set the value of a certain field.",
+    "description": "Set the value of a field.",
+    "version": "v0.1.0",
+    "author": "Meroxa, Inc.",
+    "parameters": {
+      "field": {
+        "default": "",
+        "description": "Field is the target field.",
+        "type": "string",
+        "validations": [
+          {
+            "type": "required",
+            "value": ""
+          }
+        ]
+      }
+    }
+  },
+  "examples": []
+}
+`,
+	}, {
+		name: "existing examples",
+		mode: "serve",
+		current: specFiles{
+			"field.set.json": []byte(`{"specification":{},"examples":[{"summary":"Set a field"}]}`),
+		},
+		want: `"examples": [
+    {
+      "summary": "Set a field"
+    }
+  ]`,
+	}, {
+		name:    "binary fails",
+		mode:    "fail",
+		wantErr: "exited before its API was available",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(fakeConduitEnv, tc.mode)
+
+			got, err := conduitSpecs(context.Background(), os.Args[0], "", tc.current)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("expected 1 specification, got %d", len(got))
+			}
+			if raw := string(got["field.set.json"]); !strings.Contains(raw, tc.want) {
+				t.Errorf("expected field.set.json to contain\n%s\ngot\n%s", tc.want, raw)
+			}
+		})
+	}
+}
+
+func TestPluginSpecification(t *testing.T) {
+	testCases := []struct {
+		name   string
+		plugin string
+		want   string
+	}{{
+		name:   "builtin",
+		plugin: `{"name":"builtin:json.decode@v0.1.0","version":"v0.1.0","parameters":{"field":{"type":"TYPE_STRING","validations":[{"type":"TYPE_GREATER_THAN","value":"0"}]}}}`,
+		want:   `{"name":"json.decode","summary":"","description":"","version":"v0.1.0","author":"","parameters":{"field":{"default":"","description":"","type":"string","validations":[{"type":"greater-than","value":"0"}]}}}`,
+	}, {
+		name:   "standalone",
+		plugin: `{"name":"standalone:custom@v1.0.0","parameters":{"count":{"type":"TYPE_INT"}}}`,
+		want:   `{"name":"custom","summary":"","description":"","version":"","author":"","parameters":{"count":{"default":"","description":"","type":"int","validations":[]}}}`,
+	}, {
+		name:   "no parameters",
+		plugin: `{"name":"builtin:unwrap.opencdc@v0.1.0"}`,
+		want:   `{"name":"unwrap.opencdc","summary":"","description":"","version":"","author":"","parameters":{}}`,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var plugin map[string]any
+			if err := json.Unmarshal([]byte(tc.plugin), &plugin); err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(pluginSpecification(plugin))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("expected\n%s\ngot\n%s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,492 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// processorSpec is the contents of a processor specification file, as
+// written by Conduit to pkg/plugin/processor/builtin/internal/exampleutil/specs.
+type processorSpec struct {
+	Specification specification `json:"specification"`
+	Examples      []example     `json:"examples"`
+
+	// File is the name of the file the specification was read from.
+	File string `json:"-"`
+}
+
+type specification struct {
+	Name        string               `json:"name"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Version     string               `json:"version"`
+	Author      string               `json:"author"`
+	Parameters  map[string]parameter `json:"parameters"`
+}
+
+type parameter struct {
+	Default     string       `json:"default"`
+	Description string       `json:"description"`
+	Type        string       `json:"type"`
+	Validations []validation `json:"validations"`
+}
+
+type validation struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// example shows how the processor transforms a record with a certain
+// configuration.
+type example struct {
+	Summary     string            `json:"summary"`
+	Description string            `json:"description"`
+	Config      map[string]string `json:"config"`
+	Have        *exampleRecord    `json:"have"`
+	Want        exampleOutput     `json:"want"`
+}
+
+// exampleRecord is an OpenCDC record in an example. The order of the fields
+// is the order in which they are rendered.
+type exampleRecord struct {
+	Position  any            `json:"position"`
+	Operation string         `json:"operation"`
+	Metadata  any            `json:"metadata"`
+	Key       any            `json:"key"`
+	Payload   examplePayload `json:"payload"`
+}
+
+type examplePayload struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// exampleOutput is the result of processing the example record. Depending on
+// the processor it's a single record, multiple records (e.g. split), an error
+// or nothing, if the record was filtered out.
+type exampleOutput struct {
+	Records []exampleRecord
+	// Multiple is true if the output is a list of records, even if it
+	// contains a single record.
+	Multiple bool
+	Error    string
+}
+
+func (o *exampleOutput) UnmarshalJSON(raw []byte) error {
+	*o = exampleOutput{}
+	raw = bytes.TrimSpace(raw)
+	switch {
+	case bytes.Equal(raw, []byte("null")):
+		return nil
+	case bytes.HasPrefix(raw, []byte("[")):
+		o.Multiple = true
+		if err := decodeJSON(raw, &o.Records); err != nil {
+			return wantError(err)
+		}
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return wantError(err)
+	}
+	switch {
+	case len(fields) == 0:
+		// filtered out
+		return nil
+	case fields["error"] != nil:
+		if len(fields) > 1 {
+			return errors.New("want: an error can't be combined with other fields")
+		}
+		if err := json.Unmarshal(fields["error"], &o.Error); err != nil {
+			return wantError(err)
+		}
+		return nil
+	}
+	o.Records = make([]exampleRecord, 1)
+	if err := decodeJSON(raw, &o.Records[0]); err != nil {
+		return wantError(err)
+	}
+	return nil
+}
+
+func (o exampleOutput) MarshalJSON() ([]byte, error) {
+	switch {
+	case o.Error != "":
+		return json.Marshal(map[string]string{"error": o.Error})
+	case o.Multiple:
+		return json.Marshal(o.Records)
+	case len(o.Records) == 1:
+		return json.Marshal(o.Records[0])
+	default:
+		return []byte("{}"), nil
+	}
+}
+
+// wantError adds the want field to a decoding error, nested type errors keep
+// pointing to the field causing them.
+func wantError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		typeErr.Field = strings.TrimSuffix("want."+typeErr.Field, ".")
+		return typeErr
+	}
+	return fmt.Errorf("want: %w", err)
+}
+
+// decodeJSON decodes JSON into v, fields that don't exist in v are ignored
+// (see unknownFields). Numbers are kept as json.Number, so large integers in
+// records don't lose precision.
+func decodeJSON(raw []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// unknownFields returns the paths of the fields in raw that don't exist in t
+// (e.g. specification.license). Like encoding/json, field names are matched
+// case-insensitively. Values that don't have the shape of t are skipped, as
+// decoding them fails anyway.
+func unknownFields(raw json.RawMessage, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeFor[exampleOutput]() {
+		// a list of records, a single record or an error
+		var output struct {
+			Error json.RawMessage `json:"error"`
+		}
+		switch {
+		case bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")):
+			t = reflect.TypeFor[[]exampleRecord]()
+		case json.Unmarshal(raw, &output) == nil && output.Error != nil:
+			return nil
+		default:
+			t = reflect.TypeFor[exampleRecord]()
+		}
+	}
+
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(raw, &fields) != nil {
+			return nil
+		}
+		for _, key := range slices.Sorted(maps.Keys(fields)) {
+			fieldPath := strings.TrimPrefix(path+"."+key, ".")
+			field, ok := jsonField(t, key)
+			if !ok {
+				unknown = append(unknown, fieldPath)
+				continue
+			}
+			unknown = append(unknown, unknownFields(fields[key], field.Type, fieldPath)...)
+		}
+	case reflect.Map:
+		var values map[string]json.RawMessage
+		if json.Unmarshal(raw, &values) != nil {
+			return nil
+		}
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			unknown = append(unknown, unknownFields(values[key], t.Elem(), path+"."+key)...)
+		}
+	case reflect.Slice:
+		var values []json.RawMessage
+		if json.Unmarshal(raw, &values) != nil {
+			return nil
+		}
+		for i, value := range values {
+			unknown = append(unknown, unknownFields(value, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return unknown
+}
+
+// jsonField returns the field of the struct type t that key is decoded into.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// specProblem is a problem in a processor specification file, field is the
+// JSON path of the field causing it (e.g. examples[0].config). Warnings are
+// logged, but don't stop the generator.
+type specProblem struct {
+	file    string
+	field   string
+	message string
+	warning bool
+}
+
+func (p specProblem) Error() string {
+	if p.field == "" {
+		return fmt.Sprintf("%s: %s", p.file, p.message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.file, p.field, p.message)
+}
+
+// readSpecs reads and validates all processor specifications in inputPath,
+// sorted by name. Problems in all files are collected and returned together,
+// so they can be fixed at once.
+func readSpecs(inputPath string) ([]processorSpec, error) {
+	inputFiles, err := os.ReadDir(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input folder: %w", err)
+	}
+
+	var (
+		specs []processorSpec
+		errs  []error
+	)
+	for _, dirEntry := range inputFiles {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), ".json") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(inputPath, dirEntry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", dirEntry.Name(), err)
+		}
+		spec, problems := parseSpec(dirEntry.Name(), raw)
+		if problems = logWarnings(problems); len(problems) > 0 {
+			for _, p := range problems {
+				errs = append(errs, p)
+			}
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("found %d problem(s) in specifications:\n%w", len(errs), errors.Join(errs...))
+	}
+
+	// sort by name, so the output doesn't depend on the file names
+	slices.SortStableFunc(specs, func(a, b processorSpec) int {
+		return strings.Compare(a.Specification.Name, b.Specification.Name)
+	})
+	return specs, nil
+}
+
+// parseSpec decodes and validates a processor specification file. The
+// specification and each example are decoded separately, so a decoding
+// problem in one example doesn't hide problems in the others.
+func parseSpec(file string, raw []byte) (processorSpec, []specProblem) {
+	spec := processorSpec{File: file}
+
+	var parts struct {
+		Specification json.RawMessage   `json:"specification"`
+		Examples      []json.RawMessage `json:"examples"`
+	}
+	if err := decodeJSON(raw, &parts); err != nil {
+		return spec, []specProblem{decodeProblem(file, "", err)}
+	}
+
+	// continue validating the parts that could be decoded, so all problems
+	// are reported at once
+	var (
+		problems []specProblem
+		skip     = make(map[string]bool)
+	)
+	if err := decodeJSON(parts.Specification, &spec.Specification); err != nil {
+		problems = append(problems, decodeProblem(file, "specification", err))
+		skip["specification"] = true
+	}
+	spec.Examples = make([]example, len(parts.Examples))
+	for i, raw := range parts.Examples {
+		if err := decodeJSON(raw, &spec.Examples[i]); err != nil {
+			field := fmt.Sprintf("examples[%d]", i)
+			problems = append(problems, decodeProblem(file, field, err))
+			skip[field] = true
+		}
+	}
+	for _, p := range validateSpec(spec) {
+		prefix, _, _ := strings.Cut(p.field, ".")
+		if !skip[prefix] {
+			problems = append(problems, p)
+		}
+	}
+	// newer Conduit versions can add fields, which shouldn't break the docs
+	for _, field := range unknownFields(raw, reflect.TypeFor[processorSpec](), "") {
+		problems = append(problems, specProblem{file: file, field: field, message: "unknown field, ignored", warning: true})
+	}
+	return spec, problems
+}
+
+// logWarnings logs the warnings in problems and returns the other problems.
+func logWarnings(problems []specProblem) []specProblem {
+	var errs []specProblem
+	for _, p := range problems {
+		if p.warning {
+			log.Printf("⚠️  %v", p)
+			continue
+		}
+		errs = append(errs, p)
+	}
+	return errs
+}
+
+// decodeProblem converts a JSON decoding error of the field at path into a
+// problem, pointing to the nested field if the error contains it.
+func decodeProblem(file, path string, err error) specProblem {
+	problem := specProblem{file: file, field: path, message: err.Error()}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if typeErr.Field != "" {
+			problem.field = strings.TrimPrefix(path+"."+typeErr.Field, ".")
+		}
+		problem.message = fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)
+	}
+	return problem
+}
+
+// Parameter and validation types supported by Conduit.
+var (
+	parameterTypes  = []string{"string", "int", "float", "bool", "duration", "file"}
+	validationTypes = []string{"required", "greater-than", "less-than", "inclusion", "exclusion", "regex"}
+)
+
+// validateSpec checks the specification for problems that would result in a
+// broken or misleading page.
+func validateSpec(spec processorSpec) []specProblem {
+	var problems []specProblem
+	problem := func(field, format string, args ...any) {
+		problems = append(problems, specProblem{file: spec.File, field: field, message: fmt.Sprintf(format, args...)})
+	}
+
+	s := spec.Specification
+	switch {
+	case s.Name == "":
+		problem("specification.name", "is required")
+	case s.Name+".json" != spec.File:
+		problem("specification.name", "%q doesn't match the file name", s.Name)
+	}
+	if strings.TrimSpace(s.Summary) == "" {
+		problem("specification.summary", "is required")
+	}
+	if s.Version == "" {
+		problem("specification.version", "is required")
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(s.Parameters)) {
+		p := s.Parameters[name]
+		field := "specification.parameters." + name
+		if !slices.Contains(parameterTypes, p.Type) {
+			problem(field+".type", "unknown type %q, expected one of %s", p.Type, strings.Join(parameterTypes, ", "))
+		} else if err := checkParameterValue(p.Type, p.Default); err != nil {
+			problem(field+".default", "%v", err)
+		} else if err := checkParameterSyntax(kindOf(s.Name, name, p), p.Default); err != nil {
+			problem(field+".default", "%v", err)
+		}
+		for i, v := range p.Validations {
+			vField := fmt.Sprintf("%s.validations[%d]", field, i)
+			switch v.Type {
+			case "greater-than", "less-than":
+				if _, err := strconv.ParseFloat(v.Value, 64); err != nil {
+					problem(vField+".value", "%q is not a number", v.Value)
+				}
+			case "regex":
+				if _, err := regexp.Compile(v.Value); err != nil {
+					problem(vField+".value", "invalid regex: %v", err)
+				}
+			default:
+				if !slices.Contains(validationTypes, v.Type) {
+					problem(vField+".type", "unknown validation %q, expected one of %s", v.Type, strings.Join(validationTypes, ", "))
+				}
+			}
+		}
+	}
+
+	for i, e := range spec.Examples {
+		field := fmt.Sprintf("examples[%d]", i)
+		if strings.TrimSpace(e.Summary) == "" {
+			problem(field+".summary", "is required")
+		}
+		for _, key := range slices.Sorted(maps.Keys(e.Config)) {
+			p, ok := lookupParameter(s.Parameters, key)
+			if !ok {
+				problem(field+".config."+key, "unknown parameter")
+				continue
+			}
+			if err := checkParameterSyntax(kindOf(s.Name, key, p), e.Config[key]); err != nil {
+				problem(field+".config."+key, "%v", err)
+			}
+		}
+		if e.Have == nil {
+			problem(field+".have", "is required")
+		}
+	}
+	return problems
+}
+
+// checkParameterValue checks that a non-empty value can be parsed as the
+// parameter type.
+func checkParameterValue(typ, value string) error {
+	if value == "" {
+		return nil
+	}
+	var err error
+	switch typ {
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "float":
+		_, err = strconv.ParseFloat(value, 64)
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return fmt.Errorf("%q is not a valid %s", value, typ)
+	}
+	return nil
+}
+
+// lookupParameter returns the parameter matching the configuration key. A
+// wildcard in a parameter name (e.g. headers.*) matches a single segment of
+// the key.
+func lookupParameter(params map[string]parameter, key string) (parameter, bool) {
+	if p, ok := params[key]; ok {
+		return p, true
+	}
+	keyTokens := strings.Split(key, ".")
+	for name, p := range params {
+		nameTokens := strings.Split(name, ".")
+		if !strings.Contains(name, "*") || len(nameTokens) != len(keyTokens) {
+			continue
+		}
+		match := true
+		for i, t := range nameTokens {
+			if t != "*" && t != keyTokens[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return p, true
+		}
+	}
+	return parameter{}, false
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExampleOutputUnmarshalJSON(t *testing.T) {
+	record := exampleRecord{
+		Position:  "cG9z",
+		Operation: "create",
+		Metadata:  map[string]any{"foo": "bar"},
+		Payload:   examplePayload{After: map[string]any{"id": json.Number("1")}},
+	}
+	recordJSON := `{"position":"cG9z","operation":"create","metadata":{"foo":"bar"},"key":null,"payload":{"before":null,"after":{"id":1}}}`
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    exampleOutput
+		wantErr string
+		// wantField is the field of a type error.
+		wantField string
+	}{{
+		name: "null",
+		raw:  "null",
+		want: exampleOutput{},
+	}, {
+		name: "filtered",
+		raw:  "{}",
+		want: exampleOutput{},
+	}, {
+		name: "single record",
+		raw:  recordJSON,
+		want: exampleOutput{Records: []exampleRecord{record}},
+	}, {
+		name: "multiple records",
+		raw:  "[" + recordJSON + "," + recordJSON + "]",
+		want: exampleOutput{Records: []exampleRecord{record, record}, Multiple: true},
+	}, {
+		name: "list with a single record",
+		raw:  "[" + recordJSON + "]",
+		want: exampleOutput{Records: []exampleRecord{record}, Multiple: true},
+	}, {
+		name: "error",
+		raw:  `{"error":"field not found"}`,
+		want: exampleOutput{Error: "field not found"},
+	}, {
+		name:    "error combined with a record",
+		raw:     `{"error":"field not found","operation":"create"}`,
+		wantErr: "want: an error can't be combined with other fields",
+	}, {
+		name:      "error is not a string",
+		raw:       `{"error":1}`,
+		wantField: "want",
+	}, {
+		// reported by unknownFields
+		name: "unknown field",
+		raw:  `{"operation":"create","payload":{"after":null,"foo":1}}`,
+		want: exampleOutput{Records: []exampleRecord{{Operation: "create"}}},
+	}, {
+		name:      "invalid type in a record",
+		raw:       `{"operation":1}`,
+		wantField: "want.operation",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got exampleOutput
+			err := json.Unmarshal([]byte(tt.raw), &got)
+			if tt.wantField != "" {
+				var typeErr *json.UnmarshalTypeError
+				if !errors.As(err, &typeErr) || typeErr.Field != tt.wantField {
+					t.Fatalf("expected type error in field %q, got %v", tt.wantField, err)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExampleOutputRoundTrip(t *testing.T) {
+	for _, raw := range []string{
+		`{}`,
+		`{"error":"failed"}`,
+		`{"position":null,"operation":"create","metadata":null,"key":null,"payload":{"before":null,"after":null}}`,
+		`[{"position":null,"operation":"create","metadata":null,"key":null,"payload":{"before":null,"after":null}}]`,
+	} {
+		var o exampleOutput
+		if err := json.Unmarshal([]byte(raw), &o); err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != raw {
+			t.Errorf("expected %s, got %s", raw, got)
+		}
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	const valid = `{
+  "specification": {
+    "name": "field.set",
+    "summary": "Set a field.",
+    "description": "",
+    "version": "v0.1.0",
+    "author": "Meroxa, Inc.",
+    "parameters": {
+      "field": {"default": "", "description": "", "type": "string", "validations": [{"type": "required", "value": ""}]},
+      "value": {"default": "", "description": "Go template", "type": "string", "validations": []},
+      "headers.*": {"default": "", "description": "", "type": "string", "validations": []}
+    }
+  },
+  "examples": [{
+    "summary": "Set the operation",
+    "description": "",
+    "config": {"field": ".Operation", "value": "update", "headers.x": "y"},
+    "have": {"position": null, "operation": "create", "metadata": null, "key": null, "payload": {"before": null, "after": null}},
+    "want": {"position": null, "operation": "update", "metadata": null, "key": null, "payload": {"before": null, "after": null}}
+  }]
+}`
+
+	tests := []struct {
+		name string
+		file string
+		// replace is applied to the valid specification.
+		replace  []string
+		want     []string
+		warnings []string
+	}{{
+		name: "valid",
+		file: "field.set.json",
+	}, {
+		name: "file name",
+		file: "field.json",
+		want: []string{`field.json: specification.name: "field.set" doesn't match the file name`},
+	}, {
+		name:    "specification fields",
+		file:    "field.set.json",
+		replace: []string{`"summary": "Set a field."`, `"summary": " "`, `"version": "v0.1.0"`, `"version": ""`},
+		want: []string{
+			"field.set.json: specification.summary: is required",
+			"field.set.json: specification.version: is required",
+		},
+	}, {
+		name: "parameters",
+		file: "field.set.json",
+		replace: []string{
+			`"type": "required"`, `"type": "requird"`,
+			`"default": "", "description": "Go template"`, `"default": "{{ .Key ", "description": "Go template"`,
+			`"headers.*": {"default": "", "description": "", "type": "string"`, `"headers.*": {"default": "", "description": "", "type": "text"`,
+		},
+		want: []string{
+			`field.set.json: specification.parameters.field.validations[0].type: unknown validation "requird", expected one of required, greater-than, less-than, inclusion, exclusion, regex`,
+			`field.set.json: specification.parameters.headers.*.type: unknown type "text", expected one of string, int, float, bool, duration, file`,
+			`field.set.json: specification.parameters.value.default: invalid template: template: value:1: unclosed action`,
+		},
+	}, {
+		name:    "example config",
+		file:    "field.set.json",
+		replace: []string{`"field": ".Operation"`, `"field": "Operation"`, `"headers.x": "y"`, `"header": "y"`},
+		want: []string{
+			"field.set.json: examples[0].config.field: invalid reference \"Operation\": expected . or [",
+			"field.set.json: examples[0].config.header: unknown parameter",
+		},
+	}, {
+		// the example can't be decoded, so it isn't validated, but the
+		// specification still is
+		name:    "decoding problem",
+		file:    "field.set.json",
+		replace: []string{`"summary": "Set the operation"`, `"summary": ""`, `"operation": "update"`, `"operation": 1`, `"version": "v0.1.0"`, `"version": ""`},
+		want: []string{
+			"field.set.json: examples[0].want.operation: expected string, got number",
+			"field.set.json: specification.version: is required",
+		},
+	}, {
+		name: "unknown fields",
+		file: "field.set.json",
+		replace: []string{
+			`"author": "Meroxa, Inc."`, `"author": "Meroxa, Inc.", "license": "Apache"`,
+			`"operation": "update"`, `"operation": "update", "schema": {}`,
+		},
+		warnings: []string{
+			"field.set.json: examples[0].want.schema: unknown field, ignored",
+			"field.set.json: specification.license: unknown field, ignored",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := strings.NewReplacer(tt.replace...).Replace(valid)
+			spec, problems := parseSpec(tt.file, []byte(raw))
+
+			var got, warnings []string
+			for _, p := range problems {
+				if p.warning {
+					warnings = append(warnings, p.Error())
+				} else {
+					got = append(got, p.Error())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected problems:\n%s\ngot:\n%s", strings.Join(tt.want, "\n"), strings.Join(got, "\n"))
+			}
+			if !reflect.DeepEqual(warnings, tt.warnings) {
+				t.Errorf("expected warnings:\n%s\ngot:\n%s", strings.Join(tt.warnings, "\n"), strings.Join(warnings, "\n"))
+			}
+			if len(tt.want) == 0 && (spec.Specification.Name != "field.set" || len(spec.Examples) != 1) {
+				t.Errorf("unexpected specification %+v", spec)
+			}
+		})
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{{
+		name: "known fields",
+		raw:  `{"specification":{"Name":"field.set","parameters":{"field":{"type":"string"}}},"examples":[{"want":{"error":"failed"}}]}`,
+	}, {
+		name: "nested fields",
+		raw:  `{"File":"x","specification":{"parameters":{"headers.*":{"type":"string","deprecated":true}}},"examples":[{"have":{"schema":{}},"want":[{},{"payload":{"diff":null}}]}]}`,
+		want: []string{
+			"File",
+			"examples[0].have.schema",
+			"examples[0].want[1].payload.diff",
+			"specification.parameters.headers.*.deprecated",
+		},
+	}, {
+		name: "unexpected shape",
+		raw:  `{"specification":[],"examples":{"foo":1}}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unknownFields(json.RawMessage(tt.raw), reflect.TypeFor[processorSpec](), "")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLookupParameter(t *testing.T) {
+	params := map[string]parameter{
+		"url":                {Type: "string"},
+		"headers.*":          {Type: "string"},
+		"sdk.schema.*.field": {Type: "bool"},
+	}
+	tests := map[string]string{
+		"url":                   "string",
+		"headers.Authorization": "string",
+		"sdk.schema.key.field":  "bool",
+		"headers":               "",
+		"headers.a.b":           "",
+		"sdk.schema.key.other":  "",
+	}
+	for key, want := range tests {
+		p, ok := lookupParameter(params, key)
+		if ok != (want != "") || p.Type != want {
+			t.Errorf("lookupParameter(%q): expected %q, got %q (found: %v)", key, want, p.Type, ok)
+		}
+	}
+}
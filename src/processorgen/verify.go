@@ -0,0 +1,807 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// referenceProcessor processes a record the same way as the builtin processor
+// with the same name. It returns the resulting records, no records if the
+// record is filtered out, or an error if the processor fails the record.
+type referenceProcessor func(config map[string]string, rec refRecord) ([]refRecord, error)
+
+// referenceProcessors contains Go implementations of the deterministic builtin
+// processors, they are used to verify the examples in the specifications.
+// Processors that depend on external services or non-deterministic input
+// (e.g. webhook.http, openai.*, avro.*) are not verified.
+var referenceProcessors = map[string]referenceProcessor{
+	"base64.decode":       processBase64Decode,
+	"base64.encode":       processBase64Encode,
+	"clone":               processClone,
+	"field.convert":       processFieldConvert,
+	"field.exclude":       processFieldExclude,
+	"field.rename":        processFieldRename,
+	"field.set":           processFieldSet,
+	"filter":              processFilter,
+	"json.decode":         processJSONDecode,
+	"json.encode":         processJSONEncode,
+	"split":               processSplit,
+	"unwrap.debezium":     processUnwrapDebezium,
+	"unwrap.kafkaconnect": processUnwrapKafkaConnect,
+	"unwrap.opencdc":      processUnwrapOpenCDC,
+}
+
+// verifyExamples runs the examples of all processors with a reference
+// implementation and checks that the output matches want. It returns the
+// number of verified examples.
+func verifyExamples(specs []processorSpec) (int, error) {
+	var (
+		verified int
+		errs     []error
+	)
+	for _, spec := range specs {
+		process, ok := referenceProcessors[spec.Specification.Name]
+		if !ok {
+			continue
+		}
+		for i, e := range spec.Examples {
+			if err := verifyExample(spec.Specification, e, process); err != nil {
+				errs = append(errs, specProblem{
+					file:    spec.File,
+					field:   fmt.Sprintf("examples[%d]", i),
+					message: fmt.Sprintf("%s: %v", e.Summary, err),
+				})
+			}
+			verified++
+		}
+	}
+	if len(errs) > 0 {
+		return verified, fmt.Errorf("found %d example(s) that don't match their processor:\n%w", len(errs), errors.Join(errs...))
+	}
+	return verified, nil
+}
+
+// verifyExample processes the have record of the example and compares the
+// result with want.
+func verifyExample(spec specification, e example, process referenceProcessor) error {
+	if e.Have == nil {
+		return errors.New("have is missing")
+	}
+	rec, err := toRefRecord(*e.Have)
+	if err != nil {
+		return err
+	}
+
+	// apply defaults, like Conduit does when configuring the processor
+	config := make(map[string]string)
+	for name, p := range spec.Parameters {
+		if p.Default != "" && !strings.Contains(name, "*") {
+			config[name] = p.Default
+		}
+	}
+	maps.Copy(config, e.Config)
+
+	var got exampleOutput
+	out, err := process(config, rec)
+	switch {
+	case err != nil:
+		got.Error = err.Error()
+	default:
+		got.Multiple = e.Want.Multiple || len(out) > 1
+		for _, r := range out {
+			converted, err := fromRefRecord(r)
+			if err != nil {
+				return err
+			}
+			got.Records = append(got.Records, converted)
+		}
+	}
+
+	gotJSON, err := canonicalJSON(got)
+	if err != nil {
+		return err
+	}
+	wantJSON, err := canonicalJSON(e.Want)
+	if err != nil {
+		return err
+	}
+	if gotJSON != wantJSON {
+		return fmt.Errorf("output differs from want\n    want: %s\n    got:  %s", wantJSON, gotJSON)
+	}
+	return nil
+}
+
+// canonicalJSON encodes v as compact JSON with sorted object keys and
+// normalized numbers, so equal values have the same encoding.
+func canonicalJSON(v any) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal record: %w", err)
+	}
+	var decoded any
+	if err := decodeNumbers(raw, &decoded); err != nil {
+		return "", fmt.Errorf("failed to unmarshal record: %w", err)
+	}
+	out, err := json.Marshal(normalizeNumbers(decoded))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal record: %w", err)
+	}
+	return string(out), nil
+}
+
+// normalizeNumbers replaces numbers that are integers with their integer
+// representation (e.g. 1.0 becomes 1).
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalizeNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return v
+		}
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			return json.Number(strconv.FormatInt(int64(f), 10))
+		}
+	}
+	return v
+}
+
+// decodeNumbers decodes JSON and keeps numbers as json.Number, so large
+// integers don't lose precision.
+func decodeNumbers(raw []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// refRecord is an OpenCDC record as processed by the reference processors, a
+// map with the fields position, operation, metadata, key and payload (with
+// before and after). Structured data is a map, raw data a string.
+type refRecord map[string]any
+
+func toRefRecord(rec exampleRecord) (refRecord, error) {
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record: %w", err)
+	}
+	var r refRecord
+	if err := decodeNumbers(raw, &r); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal record: %w", err)
+	}
+	return r, nil
+}
+
+func fromRefRecord(r refRecord) (exampleRecord, error) {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return exampleRecord{}, fmt.Errorf("failed to marshal record: %w", err)
+	}
+	var rec exampleRecord
+	if err := decodeNumbers(raw, &rec); err != nil {
+		return exampleRecord{}, fmt.Errorf("failed to unmarshal record: %w", err)
+	}
+	return rec, nil
+}
+
+func (r refRecord) clone() refRecord {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		panic(fmt.Errorf("failed to clone record: %w", err))
+	}
+	var c refRecord
+	if err := decodeNumbers(raw, &c); err != nil {
+		panic(fmt.Errorf("failed to clone record: %w", err))
+	}
+	return c
+}
+
+// setMetadata sets a metadata field, metadata is created if it's null.
+func (r refRecord) setMetadata(key, value string) {
+	md, _ := r["metadata"].(map[string]any)
+	if md == nil {
+		md = make(map[string]any)
+		r["metadata"] = md
+	}
+	md[key] = value
+}
+
+// reference is a parsed field reference (e.g. .Payload.After.foo), the path
+// contains the keys of the field in a refRecord.
+type reference []string
+
+// parseReference parses a field reference like .Metadata.foo or
+// .Payload.After["foo.bar"].
+func parseReference(ref string) (reference, error) {
+	var tokens []string
+	rest := strings.TrimSpace(ref)
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid reference %q: empty field name", ref)
+			}
+			tokens = append(tokens, rest[:end])
+			rest = rest[end:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("invalid reference %q: missing ]", ref)
+			}
+			key, err := strconv.Unquote(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid reference %q: %w", ref, err)
+			}
+			tokens = append(tokens, key)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid reference %q: expected . or [", ref)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("invalid reference %q", ref)
+	}
+
+	var path reference
+	switch tokens[0] {
+	case "Position", "Operation", "Metadata", "Key":
+		path = reference{strings.ToLower(tokens[0])}
+		tokens = tokens[1:]
+	case "Payload":
+		path = reference{"payload"}
+		tokens = tokens[1:]
+		if len(tokens) > 0 {
+			if tokens[0] != "Before" && tokens[0] != "After" {
+				return nil, fmt.Errorf("invalid reference %q: expected .Payload.Before or .Payload.After", ref)
+			}
+			path = append(path, strings.ToLower(tokens[0]))
+			tokens = tokens[1:]
+		}
+	default:
+		return nil, fmt.Errorf("invalid reference %q: unknown field %s", ref, tokens[0])
+	}
+	if len(tokens) > 0 && (path[0] == "position" || path[0] == "operation" || len(path) == 1 && path[0] == "payload") {
+		return nil, fmt.Errorf("invalid reference %q: %s has no nested fields", ref, path[0])
+	}
+	return append(path, tokens...), nil
+}
+
+// parent returns the map containing the referenced field, intermediate maps
+// are created if create is true.
+func (r refRecord) parent(path reference, create bool) (map[string]any, error) {
+	m := map[string]any(r)
+	for i, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			if m[key] != nil || !create {
+				return nil, fmt.Errorf("%s is not structured data", strings.Join(path[:i+1], "."))
+			}
+			next = make(map[string]any)
+			m[key] = next
+		}
+		m = next
+	}
+	return m, nil
+}
+
+func (r refRecord) get(path reference) (any, error) {
+	m, err := r.parent(path, false)
+	if err != nil {
+		return nil, err
+	}
+	return m[path[len(path)-1]], nil
+}
+
+func (r refRecord) set(path reference, value any) error {
+	if path[0] == "metadata" && len(path) == 2 {
+		s, ok := value.(string)
+		if !ok {
+			s = formatValue(value)
+		}
+		value = s
+	}
+	m, err := r.parent(path, true)
+	if err != nil {
+		return err
+	}
+	m[path[len(path)-1]] = value
+	return nil
+}
+
+func (r refRecord) delete(path reference) error {
+	switch {
+	case len(path) == 1 && path[0] == "metadata":
+		r["metadata"] = map[string]any{}
+		return nil
+	case len(path) == 1 && path[0] == "payload":
+		r["payload"] = map[string]any{"before": nil, "after": nil}
+		return nil
+	case len(path) == 1 || len(path) == 2 && path[0] == "payload":
+		// data fields are reset
+		return r.set(path, nil)
+	}
+	m, err := r.parent(path, false)
+	if err != nil {
+		return err
+	}
+	delete(m, path[len(path)-1])
+	return nil
+}
+
+// formatValue formats a value as a string, like fmt.Sprint, but encodes
+// structured values as JSON.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func referenceParam(config map[string]string, name string) (reference, error) {
+	if config[name] == "" {
+		return nil, fmt.Errorf("parameter %s is required", name)
+	}
+	return parseReference(config[name])
+}
+
+func processFilter(map[string]string, refRecord) ([]refRecord, error) {
+	return nil, nil
+}
+
+func processFieldSet(config map[string]string, rec refRecord) ([]refRecord, error) {
+	field, err := referenceParam(config, "field")
+	if err != nil {
+		return nil, err
+	}
+	if field[0] == "position" {
+		return nil, errors.New("it is not allowed to set the .Position field")
+	}
+
+	t, err := template.New("value").Parse(config["value"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse value template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, templateRecord(rec)); err != nil {
+		return nil, fmt.Errorf("failed to execute value template: %w", err)
+	}
+	if err := rec.set(field, buf.String()); err != nil {
+		return nil, err
+	}
+	return []refRecord{rec}, nil
+}
+
+// templateRecord returns the record data passed to Go templates, fields are
+// referenced the same way as in Conduit (e.g. .Payload.After.foo).
+func templateRecord(rec refRecord) any {
+	payload, _ := rec["payload"].(map[string]any)
+	return struct {
+		Position  any
+		Operation any
+		Metadata  any
+		Key       any
+		Payload   struct{ Before, After any }
+	}{
+		Position:  rec["position"],
+		Operation: rec["operation"],
+		Metadata:  rec["metadata"],
+		Key:       rec["key"],
+		Payload:   struct{ Before, After any }{Before: payload["before"], After: payload["after"]},
+	}
+}
+
+func processFieldExclude(config map[string]string, rec refRecord) ([]refRecord, error) {
+	if config["fields"] == "" {
+		return nil, errors.New("parameter fields is required")
+	}
+	for _, f := range strings.Split(config["fields"], ",") {
+		field, err := parseReference(f)
+		if err != nil {
+			return nil, err
+		}
+		if field[0] == "position" || field[0] == "operation" {
+			return nil, fmt.Errorf("it is not allowed to exclude %s", f)
+		}
+		if err := rec.delete(field); err != nil {
+			return nil, err
+		}
+	}
+	return []refRecord{rec}, nil
+}
+
+func processFieldRename(config map[string]string, rec refRecord) ([]refRecord, error) {
+	if config["mapping"] == "" {
+		return nil, errors.New("parameter mapping is required")
+	}
+	for _, pair := range strings.Split(config["mapping"], ",") {
+		from, to, ok := strings.Cut(pair, ":")
+		if !ok || to == "" {
+			return nil, fmt.Errorf("invalid mapping %q, expected reference:newName", pair)
+		}
+		field, err := parseReference(from)
+		if err != nil {
+			return nil, err
+		}
+		if len(field) < 2 || field[0] == "payload" && len(field) < 3 {
+			return nil, fmt.Errorf("it is not allowed to rename %s", from)
+		}
+		m, err := rec.parent(field, false)
+		if err != nil {
+			return nil, err
+		}
+		if value, ok := m[field[len(field)-1]]; ok {
+			delete(m, field[len(field)-1])
+			m[to] = value
+		}
+	}
+	return []refRecord{rec}, nil
+}
+
+func processFieldConvert(config map[string]string, rec refRecord) ([]refRecord, error) {
+	field, err := referenceParam(config, "field")
+	if err != nil {
+		return nil, err
+	}
+	if field[0] != "key" && field[0] != "payload" {
+		return nil, fmt.Errorf("it is not allowed to convert %s", config["field"])
+	}
+	value, err := rec.get(field)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := convertValue(value, config["type"])
+	if err != nil {
+		return nil, err
+	}
+	if err := rec.set(field, converted); err != nil {
+		return nil, err
+	}
+	return []refRecord{rec}, nil
+}
+
+// convertValue converts a JSON value to the type supported by field.convert.
+func convertValue(value any, typ string) (any, error) {
+	// normalize the value to string, int64, float64, bool or time.Time
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			value = i
+		} else if f, err := v.Float64(); err == nil {
+			value = f
+		}
+	case nil:
+		return nil, nil
+	}
+
+	switch typ {
+	case "string":
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		default:
+			return formatValue(v), nil
+		}
+	case "int":
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(v), nil
+		case bool:
+			if v {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case "float":
+		switch v := value.(type) {
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	case "bool":
+		switch v := value.(type) {
+		case int64:
+			return v != 0, nil
+		case float64:
+			return v != 0, nil
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case "time":
+		switch v := value.(type) {
+		case int64:
+			return time.Unix(0, v).UTC(), nil
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return time.Unix(0, i).UTC(), nil
+			}
+			return time.Parse(time.RFC3339Nano, v)
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q, expected one of string, int, float, bool, time", typ)
+	}
+	return nil, fmt.Errorf("can't convert %T to %s", value, typ)
+}
+
+func processJSONDecode(config map[string]string, rec refRecord) ([]refRecord, error) {
+	field, err := referenceParam(config, "field")
+	if err != nil {
+		return nil, err
+	}
+	value, err := rec.get(field)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s is not raw data or a string", config["field"])
+	}
+	var decoded any
+	if s != "" {
+		if err := decodeNumbers([]byte(s), &decoded); err != nil {
+			return nil, fmt.Errorf("failed to decode %s as JSON: %w", config["field"], err)
+		}
+	}
+	if err := rec.set(field, decoded); err != nil {
+		return nil, err
+	}
+	return []refRecord{rec}, nil
+}
+
+func processJSONEncode(config map[string]string, rec refRecord) ([]refRecord, error) {
+	field, err := referenceParam(config, "field")
+	if err != nil {
+		return nil, err
+	}
+	value, err := rec.get(field)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s as JSON: %w", config["field"], err)
+	}
+	if err := rec.set(field, string(raw)); err != nil {
+		return nil, err
+	}
+	return []refRecord{rec}, nil
+}
+
+func processBase64Decode(config map[string]string, rec refRecord) ([]refRecord, error) {
+	field, err := referenceParam(config, "field")
+	if err != nil {
+		return nil, err
+	}
+	value, err := rec.get(field)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s is not raw data or a string", config["field"])
+	}
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s as base64: %w", config["field"], err)
+	}
+	if err := rec.set(field, string(decoded)); err != nil {
+		return nil, err
+	}
+	return []refRecord{rec}, nil
+}
+
+func processBase64Encode(config map[string]string, rec refRecord) ([]refRecord, error) {
+	field, err := referenceParam(config, "field")
+	if err != nil {
+		return nil, err
+	}
+	value, err := rec.get(field)
+	if err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(formatValue(value)))
+	if err := rec.set(field, encoded); err != nil {
+		return nil, err
+	}
+	return []refRecord{rec}, nil
+}
+
+func processSplit(config map[string]string, rec refRecord) ([]refRecord, error) {
+	field, err := referenceParam(config, "field")
+	if err != nil {
+		return nil, err
+	}
+	if len(field) < 2 || field[0] == "payload" && len(field) < 3 || field[0] == "metadata" {
+		return nil, fmt.Errorf("%s must reference a field in the key or payload", config["field"])
+	}
+	value, err := rec.get(field)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an array", config["field"])
+	}
+
+	out := make([]refRecord, len(items))
+	for i, item := range items {
+		split := rec.clone()
+		if err := split.set(field, item); err != nil {
+			return nil, err
+		}
+		split.setMetadata("split.index", strconv.Itoa(i))
+		out[i] = split
+	}
+	return out, nil
+}
+
+func processClone(config map[string]string, rec refRecord) ([]refRecord, error) {
+	count, err := strconv.Atoi(config["count"])
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("parameter count must be a positive integer, got %q", config["count"])
+	}
+	out := make([]refRecord, count+1)
+	for i := range out {
+		c := rec.clone()
+		c.setMetadata("clone.index", strconv.Itoa(i))
+		out[i] = c
+	}
+	return out, nil
+}
+
+// structuredField returns the referenced field as structured data, raw data is
+// decoded as JSON.
+func structuredField(rec refRecord, config map[string]string) (map[string]any, error) {
+	field, err := referenceParam(config, "field")
+	if err != nil {
+		return nil, err
+	}
+	value, err := rec.get(field)
+	if err != nil {
+		return nil, err
+	}
+	return structuredValue(value, config["field"])
+}
+
+func structuredValue(value any, name string) (map[string]any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		return v, nil
+	case string:
+		var m map[string]any
+		if err := decodeNumbers([]byte(v), &m); err != nil {
+			return nil, fmt.Errorf("failed to decode %s as JSON: %w", name, err)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("%s is neither structured nor raw data", name)
+	}
+}
+
+// unwrapKey returns the payload of a Kafka Connect or Debezium key. Strings
+// are returned as raw data, objects as structured data.
+func unwrapKey(key any) any {
+	m, err := structuredValue(key, "key")
+	if err != nil {
+		return key
+	}
+	payload, ok := m["payload"]
+	if !ok {
+		return key
+	}
+	switch p := payload.(type) {
+	case map[string]any, nil:
+		return p
+	default:
+		return formatValue(p)
+	}
+}
+
+func processUnwrapKafkaConnect(config map[string]string, rec refRecord) ([]refRecord, error) {
+	wrapped, err := structuredField(rec, config)
+	if err != nil {
+		return nil, err
+	}
+	payload, ok := wrapped["payload"].(map[string]any)
+	if !ok {
+		return nil, errors.New("kafka connect record has no payload")
+	}
+	rec["key"] = unwrapKey(rec["key"])
+	rec["payload"] = map[string]any{"before": nil, "after": payload}
+	return []refRecord{rec}, nil
+}
+
+// debeziumOperations maps Debezium operations to OpenCDC operations.
+var debeziumOperations = map[string]string{
+	"c": "create",
+	"u": "update",
+	"d": "delete",
+	"r": "snapshot",
+}
+
+func processUnwrapDebezium(config map[string]string, rec refRecord) ([]refRecord, error) {
+	wrapped, err := structuredField(rec, config)
+	if err != nil {
+		return nil, err
+	}
+	payload, ok := wrapped["payload"].(map[string]any)
+	if !ok {
+		return nil, errors.New("debezium record has no payload")
+	}
+	op, _ := payload["op"].(string)
+	operation, ok := debeziumOperations[op]
+	if !ok {
+		return nil, fmt.Errorf("unknown debezium operation %q", op)
+	}
+
+	// merge metadata, Debezium metadata has precedence
+	if source, ok := payload["source"].(map[string]any); ok {
+		for _, k := range slices.Sorted(maps.Keys(source)) {
+			rec.setMetadata(k, formatValue(source[k]))
+		}
+	}
+	rec["operation"] = operation
+	rec["key"] = unwrapKey(rec["key"])
+	rec["payload"] = map[string]any{"before": payload["before"], "after": payload["after"]}
+	return []refRecord{rec}, nil
+}
+
+func processUnwrapOpenCDC(config map[string]string, rec refRecord) ([]refRecord, error) {
+	wrapped, err := structuredField(rec, config)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range []string{"operation", "metadata", "key", "payload"} {
+		if _, ok := wrapped[f]; !ok {
+			return nil, fmt.Errorf("wrapped record has no %s", f)
+		}
+	}
+	payload, ok := wrapped["payload"].(map[string]any)
+	if !ok {
+		return nil, errors.New("wrapped record has no payload")
+	}
+
+	// the position of the wrapping record is kept
+	return []refRecord{{
+		"position":  rec["position"],
+		"operation": wrapped["operation"],
+		"metadata":  wrapped["metadata"],
+		"key":       wrapped["key"],
+		"payload":   map[string]any{"before": payload["before"], "after": payload["after"]},
+	}}, nil
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    reference
+		wantErr string
+	}{
+		{ref: ".Position", want: reference{"position"}},
+		{ref: " .Key ", want: reference{"key"}},
+		{ref: ".Metadata.foo", want: reference{"metadata", "foo"}},
+		{ref: `.Metadata["opencdc.readAt"]`, want: reference{"metadata", "opencdc.readAt"}},
+		{ref: ".Payload", want: reference{"payload"}},
+		{ref: ".Payload.After", want: reference{"payload", "after"}},
+		{ref: ".Payload.Before.foo.bar", want: reference{"payload", "before", "foo", "bar"}},
+		{ref: `.Payload.After["foo.bar"].baz`, want: reference{"payload", "after", "foo.bar", "baz"}},
+		{ref: "", wantErr: `invalid reference ""`},
+		{ref: ".", wantErr: `invalid reference ".": empty field name`},
+		{ref: ".Metadata..foo", wantErr: `invalid reference ".Metadata..foo": empty field name`},
+		{ref: "Metadata", wantErr: `invalid reference "Metadata": expected . or [`},
+		{ref: `.Metadata["foo"`, wantErr: `invalid reference ".Metadata[\"foo\"": missing ]`},
+		{ref: ".Metadata[foo]", wantErr: `invalid reference ".Metadata[foo]": invalid syntax`},
+		{ref: ".Record", wantErr: `invalid reference ".Record": unknown field Record`},
+		{ref: ".Payload.foo", wantErr: `invalid reference ".Payload.foo": expected .Payload.Before or .Payload.After`},
+		{ref: ".Position.foo", wantErr: `invalid reference ".Position.foo": position has no nested fields`},
+		{ref: ".Operation.foo", wantErr: `invalid reference ".Operation.foo": operation has no nested fields`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseReference(tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// testRecord is the record processed in TestReferenceProcessors, unless the
+// test case defines its own.
+const testRecord = `{
+  "position": "cG9zLTE=",
+  "operation": "create",
+  "metadata": {"source": "users"},
+  "key": {"id": 1},
+  "payload": {"before": null, "after": {"name": "Alice", "age": 30}}
+}`
+
+func TestReferenceProcessors(t *testing.T) {
+	tests := []struct {
+		name      string
+		processor string
+		config    map[string]string
+		have      string
+		// want is the JSON array of the returned records.
+		want    string
+		wantErr string
+	}{{
+		name:      "field.set payload",
+		processor: "field.set",
+		config:    map[string]string{"field": ".Payload.After.greeting", "value": "Hello {{ .Payload.After.name }}"},
+		want:      `[{"position":"cG9zLTE=","operation":"create","metadata":{"source":"users"},"key":{"id":1},"payload":{"before":null,"after":{"name":"Alice","age":30,"greeting":"Hello Alice"}}}]`,
+	}, {
+		name:      "field.set metadata",
+		processor: "field.set",
+		config:    map[string]string{"field": ".Metadata.op", "value": "{{ .Operation }}"},
+		want:      `[{"position":"cG9zLTE=","operation":"create","metadata":{"source":"users","op":"create"},"key":{"id":1},"payload":{"before":null,"after":{"name":"Alice","age":30}}}]`,
+	}, {
+		name:      "field.set position",
+		processor: "field.set",
+		config:    map[string]string{"field": ".Position", "value": "x"},
+		wantErr:   "it is not allowed to set the .Position field",
+	}, {
+		name:      "field.set raw data",
+		processor: "field.set",
+		config:    map[string]string{"field": ".Payload.After.name", "value": "Bob"},
+		have:      `{"position":null,"operation":"create","metadata":null,"key":null,"payload":{"before":null,"after":"raw"}}`,
+		wantErr:   "payload.after is not structured data",
+	}, {
+		name:      "field.exclude",
+		processor: "field.exclude",
+		config:    map[string]string{"fields": ".Metadata,.Key,.Payload.After.age"},
+		want:      `[{"position":"cG9zLTE=","operation":"create","metadata":{},"key":null,"payload":{"before":null,"after":{"name":"Alice"}}}]`,
+	}, {
+		name:      "field.exclude operation",
+		processor: "field.exclude",
+		config:    map[string]string{"fields": ".Operation"},
+		wantErr:   "it is not allowed to exclude .Operation",
+	}, {
+		name:      "field.rename",
+		processor: "field.rename",
+		config:    map[string]string{"mapping": ".Metadata.source:table,.Payload.After.name:firstName,.Payload.After.missing:x"},
+		want:      `[{"position":"cG9zLTE=","operation":"create","metadata":{"table":"users"},"key":{"id":1},"payload":{"before":null,"after":{"firstName":"Alice","age":30}}}]`,
+	}, {
+		name:      "field.rename top level field",
+		processor: "field.rename",
+		config:    map[string]string{"mapping": ".Key:id"},
+		wantErr:   "it is not allowed to rename .Key",
+	}, {
+		name:      "field.rename invalid mapping",
+		processor: "field.rename",
+		config:    map[string]string{"mapping": ".Metadata.source"},
+		wantErr:   `invalid mapping ".Metadata.source", expected reference:newName`,
+	}, {
+		name:      "field.convert to string",
+		processor: "field.convert",
+		config:    map[string]string{"field": ".Payload.After.age", "type": "string"},
+		want:      `[{"position":"cG9zLTE=","operation":"create","metadata":{"source":"users"},"key":{"id":1},"payload":{"before":null,"after":{"name":"Alice","age":"30"}}}]`,
+	}, {
+		name:      "field.convert to bool",
+		processor: "field.convert",
+		config:    map[string]string{"field": ".Key.id", "type": "bool"},
+		want:      `[{"position":"cG9zLTE=","operation":"create","metadata":{"source":"users"},"key":{"id":true},"payload":{"before":null,"after":{"name":"Alice","age":30}}}]`,
+	}, {
+		name:      "field.convert invalid value",
+		processor: "field.convert",
+		config:    map[string]string{"field": ".Payload.After.name", "type": "int"},
+		wantErr:   `strconv.ParseInt: parsing "Alice": invalid syntax`,
+	}, {
+		name:      "field.convert metadata",
+		processor: "field.convert",
+		config:    map[string]string{"field": ".Metadata.source", "type": "int"},
+		wantErr:   "it is not allowed to convert .Metadata.source",
+	}, {
+		name:      "unwrap.debezium",
+		processor: "unwrap.debezium",
+		config:    map[string]string{"field": ".Payload.After"},
+		have: `{
+		  "position": "cG9zLTE=",
+		  "operation": "create",
+		  "metadata": {"source": "kafka", "topic": "users"},
+		  "key": "{\"schema\":{},\"payload\":{\"id\":1}}",
+		  "payload": {"before": null, "after": {
+		    "schema": {},
+		    "payload": {"before": {"name": "Alice"}, "after": {"name": "Bob"}, "op": "u", "source": {"source": "postgres", "ts_ms": 1700000000000}}
+		  }}
+		}`,
+		want: `[{"position":"cG9zLTE=","operation":"update","metadata":{"source":"postgres","topic":"users","ts_ms":"1700000000000"},"key":{"id":1},"payload":{"before":{"name":"Alice"},"after":{"name":"Bob"}}}]`,
+	}, {
+		name:      "unwrap.debezium unknown operation",
+		processor: "unwrap.debezium",
+		config:    map[string]string{"field": ".Payload.After"},
+		have:      `{"position":null,"operation":"create","metadata":null,"key":null,"payload":{"before":null,"after":{"payload":{"op":"x"}}}}`,
+		wantErr:   `unknown debezium operation "x"`,
+	}, {
+		name:      "unwrap.debezium raw data",
+		processor: "unwrap.debezium",
+		config:    map[string]string{"field": ".Payload.After"},
+		have:      `{"position":null,"operation":"create","metadata":null,"key":null,"payload":{"before":null,"after":"not json"}}`,
+		wantErr:   "failed to decode .Payload.After as JSON: invalid character 'o' in literal null (expecting 'u')",
+	}, {
+		name:      "unwrap.kafkaconnect",
+		processor: "unwrap.kafkaconnect",
+		config:    map[string]string{"field": ".Payload.After"},
+		have: `{
+		  "position": "cG9zLTE=",
+		  "operation": "create",
+		  "metadata": {"topic": "users"},
+		  "key": {"schema": {}, "payload": 42},
+		  "payload": {"before": null, "after": "{\"schema\":{},\"payload\":{\"name\":\"Alice\"}}"}
+		}`,
+		want: `[{"position":"cG9zLTE=","operation":"create","metadata":{"topic":"users"},"key":"42","payload":{"before":null,"after":{"name":"Alice"}}}]`,
+	}, {
+		name:      "unwrap.kafkaconnect without payload",
+		processor: "unwrap.kafkaconnect",
+		config:    map[string]string{"field": ".Payload.After"},
+		wantErr:   "kafka connect record has no payload",
+	}, {
+		name:      "unwrap.opencdc",
+		processor: "unwrap.opencdc",
+		config:    map[string]string{"field": ".Payload.After"},
+		have: `{
+		  "position": "b3V0ZXI=",
+		  "operation": "create",
+		  "metadata": {"topic": "records"},
+		  "key": null,
+		  "payload": {"before": null, "after": {
+		    "position": "aW5uZXI=",
+		    "operation": "delete",
+		    "metadata": {"table": "users"},
+		    "key": "1",
+		    "payload": {"before": {"name": "Alice"}, "after": null}
+		  }}
+		}`,
+		want: `[{"position":"b3V0ZXI=","operation":"delete","metadata":{"table":"users"},"key":"1","payload":{"before":{"name":"Alice"},"after":null}}]`,
+	}, {
+		name:      "unwrap.opencdc missing field",
+		processor: "unwrap.opencdc",
+		config:    map[string]string{"field": ".Payload.After"},
+		wantErr:   "wrapped record has no operation",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			have := tt.have
+			if have == "" {
+				have = testRecord
+			}
+			var rec refRecord
+			if err := decodeNumbers([]byte(have), &rec); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := referenceProcessors[tt.processor](tt.config, rec)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want []refRecord
+			if err := decodeNumbers([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			gotJSON, err := canonicalJSON(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantJSON, err := canonicalJSON(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotJSON != wantJSON {
+				t.Errorf("expected %s, got %s", wantJSON, gotJSON)
+			}
+		})
+	}
+}